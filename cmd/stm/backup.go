@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tgienger/stm/internal/export"
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+// runBackup implements `stm backup [--format jsonl] [-o file]`
+func runBackup(args []string) int {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	format := fs.String("format", "jsonl", "output format (jsonl)")
+	output := fs.String("o", "", "write the backup to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stm backup [--format jsonl] [-o file]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+	if *format != "jsonl" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported backup format %q\n", *format)
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := export.AllJSONL(client, out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
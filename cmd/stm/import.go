@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/importer"
+)
+
+// runImport implements `stm import [--dry-run] --format <format> <file>`. The
+// jsonl format restores a backup made with `stm backup`.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "taskwarrior", "input format (taskwarrior, jsonl)")
+	dryRun := fs.Bool("dry-run", false, "print what would be imported without changing anything")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stm import [--dry-run] --format taskwarrior|jsonl <file>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	var importFn func(*fizzy.Fizzy, io.Reader, importer.Options) (importer.Result, error)
+	switch *format {
+	case "taskwarrior":
+		importFn = importer.ImportTaskwarrior
+	case "jsonl":
+		importFn = importer.ImportJSONL
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported import format %q\n", *format)
+		return 2
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	res, err := importFn(client, file, importer.Options{DryRun: *dryRun, Log: os.Stdout})
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %d cards (%d closed, %d comments) into %d new boards, skipped %d\n",
+		verb, res.Cards, res.Closed, res.Comments, res.Boards, res.Skipped)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -1,26 +1,57 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/logging"
 	"github.com/tgienger/stm/internal/ui"
-)
-
-var (
-	version = "dev"
-	commit  = "none"
-	date    = "unknown"
+	"github.com/tgienger/stm/internal/ui/styles"
 )
 
 func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
-		fmt.Printf("stm %s (commit: %s, built: %s)\n", version, commit, date)
+	logPath := flag.String("log", os.Getenv("STM_LOG"), "append debug logs to this file (or set STM_LOG)")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.BoolVar(showVersion, "v", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersion()
 		os.Exit(0)
 	}
 
+	closeLog, err := logging.Setup(*logPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeLog()
+
+	switch flag.Arg(0) {
+	case "import":
+		os.Exit(runImport(flag.Args()[1:]))
+	case "version":
+		os.Exit(runVersion(flag.Args()[1:]))
+	case "path":
+		os.Exit(runPath(flag.Args()[1:]))
+	case "show":
+		os.Exit(runShow(flag.Args()[1:]))
+	case "backup":
+		os.Exit(runBackup(flag.Args()[1:]))
+	case "tags":
+		os.Exit(runTags(flag.Args()[1:]))
+	case "today":
+		os.Exit(runToday(flag.Args()[1:]))
+	case "tag":
+		os.Exit(runTag(flag.Args()[1:], false))
+	case "untag":
+		os.Exit(runTag(flag.Args()[1:], true))
+	}
+
 	client, err := fizzy.New()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -33,11 +64,33 @@ func main() {
 		os.Exit(1)
 	}
 
+	applyTheme(settings)
+
+	slog.Info("starting", "version", version)
 	app := ui.NewApp(client, settings)
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
+		slog.Error("application error", "err", err)
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// applyTheme registers the "custom" theme from theme.custom.<field> settings, if
+// any are set, and switches to the theme named by the theme setting.
+func applyTheme(settings *fizzy.Settings) {
+	if colors := settings.WithPrefix("theme.custom."); len(colors) > 0 {
+		custom, invalid := styles.ThemeFromColors("custom", styles.TokyoNight, colors)
+		if len(invalid) > 0 {
+			slog.Warn("ignoring invalid custom theme colors", "fields", invalid)
+		}
+		styles.RegisterTheme(custom)
+	}
+
+	if name := settings.Get("theme"); name != "" {
+		if err := styles.SetTheme(name); err != nil {
+			slog.Warn("keeping default theme", "err", err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/opener"
+)
+
+// runPath implements `stm path [--open]`
+func runPath(args []string) int {
+	fs := flag.NewFlagSet("path", flag.ContinueOnError)
+	open := fs.Bool("open", false, "also open the data directory in the file manager")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		return 1
+	}
+	fmt.Println(settings.Path())
+
+	if *open {
+		if err := opener.Open(filepath.Dir(settings.Path())); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
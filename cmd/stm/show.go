@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+type showComment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type showCard struct {
+	Number      int           `json:"number"`
+	Title       string        `json:"title"`
+	Board       string        `json:"board"`
+	Column      string        `json:"column,omitempty"`
+	Closed      bool          `json:"closed"`
+	Tags        []string      `json:"tags"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Comments    []showComment `json:"comments"`
+}
+
+// runShow implements `stm show [--json] <card-number>`
+func runShow(args []string) int {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the card as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stm show [--json] <card-number>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(fs.Arg(0), "#"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid card number %q\n", fs.Arg(0))
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	board, card, err := client.FindCard(number)
+	if errors.Is(err, fizzy.ErrCardNotFound) {
+		fmt.Fprintf(os.Stderr, "Error: card #%d not found\n", number)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	comments, err := client.ListComments(number)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	out := showCard{
+		Number:      card.Number,
+		Title:       card.Title,
+		Board:       board.Name,
+		Column:      card.ColumnName,
+		Closed:      card.ColumnID == "done",
+		Tags:        card.Tags,
+		Description: card.Description,
+		CreatedAt:   card.CreatedAt,
+		Comments:    make([]showComment, len(comments)),
+	}
+	if out.Tags == nil {
+		out.Tags = []string{}
+	}
+	for i, c := range comments {
+		out.Comments[i] = showComment{Author: c.Author, Body: c.Body, CreatedAt: c.CreatedAt}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("#%d %s\n", out.Number, out.Title)
+	fmt.Printf("Board:   %s\n", out.Board)
+	if out.Column != "" {
+		fmt.Printf("Column:  %s\n", out.Column)
+	}
+	if !out.CreatedAt.IsZero() {
+		fmt.Printf("Created: %s\n", out.CreatedAt.Format("Jan 2, 2006 3:04 PM"))
+	}
+	if len(out.Tags) > 0 {
+		fmt.Printf("Tags:    %s\n", strings.Join(out.Tags, ", "))
+	}
+	if out.Description != "" {
+		fmt.Printf("\n%s\n", out.Description)
+	}
+	if len(out.Comments) > 0 {
+		fmt.Printf("\nComments (%d)\n", len(out.Comments))
+		for _, c := range out.Comments {
+			fmt.Printf("\n%s, %s\n%s\n", c.Author, c.CreatedAt.Format("Jan 2, 2006 3:04 PM"), c.Body)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+// runTag implements `stm tag <card-number> <tag>...` and, with remove set,
+// `stm untag <card-number> <tag>...`. Tags the card already has (or lacks)
+// are reported and skipped; the card's resulting tags are printed last.
+func runTag(args []string, remove bool) int {
+	name := "tag"
+	if remove {
+		name = "untag"
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: stm %s <card-number> <tag>...\n", name)
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return 2
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(fs.Arg(0), "#"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid card number %q\n", fs.Arg(0))
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	_, card, err := client.FindCard(number)
+	if errors.Is(err, fizzy.ErrCardNotFound) {
+		fmt.Fprintf(os.Stderr, "Error: card #%d not found\n", number)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	for _, tag := range fs.Args()[1:] {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" {
+			continue
+		}
+		var changed bool
+		if remove {
+			changed, err = client.RemoveTag(card, tag)
+		} else {
+			changed, err = client.AddTag(card, tag)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		switch {
+		case !changed && remove:
+			fmt.Fprintf(os.Stderr, "#%d doesn't have %s\n", card.Number, tag)
+		case !changed:
+			fmt.Fprintf(os.Stderr, "#%d already has %s\n", card.Number, tag)
+		}
+	}
+
+	if len(card.Tags) == 0 {
+		fmt.Printf("#%d: no tags\n", card.Number)
+	} else {
+		fmt.Printf("#%d: %s\n", card.Number, strings.Join(card.Tags, ", "))
+	}
+	return 0
+}
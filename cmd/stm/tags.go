@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+type tagEntry struct {
+	Title string `json:"title"`
+	Color string `json:"color,omitempty"`
+	Cards int    `json:"cards"`
+}
+
+// taxonomyEntry is one tag in the file written by `stm tags export`
+type taxonomyEntry struct {
+	Title string `json:"title"`
+	Color string `json:"color,omitempty"`
+}
+
+// runTags implements `stm tags [--json]`, plus the export and import subcommands
+func runTags(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			return runTagsExport(args[1:])
+		case "import":
+			return runTagsImport(args[1:])
+		}
+	}
+
+	fs := flag.NewFlagSet("tags", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the tags as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		return 1
+	}
+
+	tags, err := client.ListTags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	counts, err := client.TagUsageCounts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	colors := settings.TagColors()
+	entries := make([]tagEntry, len(tags))
+	for i, t := range tags {
+		entries[i] = tagEntry{
+			Title: t.Title,
+			Color: colors[strings.ToLower(t.Title)],
+			Cards: counts[t.Title],
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Title) < strings.ToLower(entries[j].Title)
+	})
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	// lipgloss already drops colors when stdout isn't a terminal; NO_COLOR
+	// turns the swatches off everywhere.
+	useColor := os.Getenv("NO_COLOR") == ""
+
+	// The swatch goes in the last column so its escape codes don't throw off
+	// tabwriter's alignment.
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, e := range entries {
+		color := e.Color
+		if useColor && color != "" {
+			color = lipgloss.NewStyle().Foreground(lipgloss.Color(e.Color)).Render("██") + " " + color
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", e.Title, e.Cards, color)
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runTagsExport implements `stm tags export [-o file]`, writing every tag and
+// its color as JSON.
+func runTagsExport(args []string) int {
+	fs := flag.NewFlagSet("tags export", flag.ContinueOnError)
+	output := fs.String("o", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		return 1
+	}
+	tags, err := client.ListTags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	colors := settings.TagColors()
+	entries := make([]taxonomyEntry, len(tags))
+	for i, t := range tags {
+		entries[i] = taxonomyEntry{Title: t.Title, Color: colors[strings.ToLower(t.Title)]}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Title) < strings.ToLower(entries[j].Title)
+	})
+
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runTagsImport implements `stm tags import <file>`. fizzy creates tags when
+// they are first used, so only the colors are applied; tags that don't exist
+// yet pick theirs up once a card is given them.
+func runTagsImport(args []string) int {
+	fs := flag.NewFlagSet("tags import", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stm tags import <file>")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	var entries []taxonomyEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a tag export: %v\n", fs.Arg(0), err)
+		return 1
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		return 1
+	}
+	tags, err := client.ListTags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	existing := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		existing[strings.ToLower(t.Title)] = true
+	}
+
+	colors := settings.TagColors()
+	changed, missing := 0, 0
+	for _, e := range entries {
+		name := strings.ToLower(strings.TrimSpace(e.Title))
+		if name == "" {
+			continue
+		}
+		if !existing[name] {
+			missing++
+		}
+		if e.Color != "" && colors[name] != e.Color {
+			colors[name] = e.Color
+			changed++
+		}
+	}
+	if err := settings.SetTagColors(colors); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving settings: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Updated %d tag colors", changed)
+	if missing > 0 {
+		fmt.Printf("; %d tags don't exist yet and will be created when first used", missing)
+	}
+	fmt.Println()
+	return 0
+}
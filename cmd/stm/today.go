@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+type todayCard struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+type todayBoard struct {
+	Board string      `json:"board"`
+	Cards []todayCard `json:"cards"`
+}
+
+// runToday implements `stm today [--json]`. Fizzy doesn't record when a card
+// was closed, so the report only knows about cards closed from stm; cards
+// closed in fizzy directly or on another machine aren't listed.
+func runToday(args []string) int {
+	fs := flag.NewFlagSet("today", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the cards as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	client, err := fizzy.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading settings: %v\n", err)
+		return 1
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	closedToday := make(map[int]bool)
+	for _, n := range settings.ClosedSince(midnight) {
+		closedToday[n] = true
+	}
+
+	report := []todayBoard{}
+	if len(closedToday) > 0 {
+		boards, err := client.ListBoards()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		for _, b := range boards {
+			cards, err := client.ListCardsByColumn(b.ID, "", true)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			// A card reopened outside stm keeps its close time, so only
+			// cards fizzy still lists as closed count.
+			entry := todayBoard{Board: b.Name}
+			for _, c := range cards {
+				if c.ColumnID != "done" || !closedToday[c.Number] {
+					continue
+				}
+				closedAt, _ := settings.ClosedAt(c.Number)
+				entry.Cards = append(entry.Cards, todayCard{Number: c.Number, Title: c.Title, ClosedAt: closedAt})
+			}
+			if len(entry.Cards) == 0 {
+				continue
+			}
+			sort.Slice(entry.Cards, func(i, j int) bool {
+				return entry.Cards[i].ClosedAt.Before(entry.Cards[j].ClosedAt)
+			})
+			report = append(report, entry)
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if len(report) == 0 {
+		fmt.Println("No cards closed today.")
+		return 0
+	}
+	for i, b := range report {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(b.Board)
+		for _, c := range b.Cards {
+			fmt.Printf("  • #%d %s (%s)\n", c.Number, c.Title, c.ClosedAt.Format("3:04 PM"))
+		}
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Populated via ldflags at build time
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+// runVersion implements `stm version [--json]`
+func runVersion(args []string) int {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print version information as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if !*asJSON {
+		printVersion()
+		return 0
+	}
+
+	info := struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit"`
+		Date    string `json:"date"`
+	}{version, commit, date}
+	if err := json.NewEncoder(os.Stdout).Encode(info); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printVersion() {
+	fmt.Printf("stm %s (commit: %s, built: %s)\n", version, commit, date)
+}
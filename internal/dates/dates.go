@@ -0,0 +1,102 @@
+package dates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseRelative turns human date input into a time relative to now. It accepts
+// "today", "tomorrow", "next week", a weekday ("fri", "next mon") meaning its
+// next occurrence after today, offsets like "+4h", "+3d" and "+2w", and
+// YYYY-MM-DD dates. Day-based results fall at midnight in now's location.
+func ParseRelative(s string, now time.Time) (time.Time, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch input {
+	case "today":
+		return midnight, nil
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), nil
+	case "next week":
+		return midnight.AddDate(0, 0, 7), nil
+	}
+
+	if day, ok := weekdays[strings.TrimPrefix(input, "next ")]; ok {
+		days := (int(day)-int(now.Weekday())+6)%7 + 1
+		return midnight.AddDate(0, 0, days), nil
+	}
+
+	if strings.HasPrefix(input, "+") && len(input) > 2 {
+		n, err := strconv.Atoi(input[1 : len(input)-1])
+		if err == nil && n > 0 {
+			switch input[len(input)-1] {
+			case 'h':
+				return now.Add(time.Duration(n) * time.Hour), nil
+			case 'd':
+				return midnight.AddDate(0, 0, n), nil
+			case 'w':
+				return midnight.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", input, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q (try +3d, tomorrow, fri or 2025-01-02)", strings.TrimSpace(s))
+}
+
+// addMonths moves t forward n months, keeping to the last day of the target
+// month when t's day doesn't exist there (Jan 31 + 1 month is Feb 28).
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// NextRecurrence returns the midnight a card repeating on rule is next due
+// after now. Rule is "daily", "weekly", "monthly", "yearly", or "every N days",
+// "every N weeks" or "every N months" (the unit may be singular).
+func NextRecurrence(rule string, now time.Time) (time.Time, error) {
+	input := strings.ToLower(strings.TrimSpace(rule))
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch input {
+	case "daily":
+		return midnight.AddDate(0, 0, 1), nil
+	case "weekly":
+		return midnight.AddDate(0, 0, 7), nil
+	case "monthly":
+		return addMonths(midnight, 1), nil
+	case "yearly":
+		return addMonths(midnight, 12), nil
+	}
+
+	if fields := strings.Fields(input); len(fields) == 3 && fields[0] == "every" {
+		n, err := strconv.Atoi(fields[1])
+		if err == nil && n > 0 {
+			switch strings.TrimSuffix(fields[2], "s") {
+			case "day":
+				return midnight.AddDate(0, 0, n), nil
+			case "week":
+				return midnight.AddDate(0, 0, 7*n), nil
+			case "month":
+				return addMonths(midnight, n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized repeat %q (try daily, weekly, monthly or every 3 days)", strings.TrimSpace(rule))
+}
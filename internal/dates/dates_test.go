@@ -0,0 +1,93 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+// now is Monday 2025-01-06, 10:30
+var now = time.Date(2025, time.January, 6, 10, 30, 0, 0, time.UTC)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRelative(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"today", day(2025, time.January, 6)},
+		{"tomorrow", day(2025, time.January, 7)},
+		{"  Tomorrow ", day(2025, time.January, 7)},
+		{"next week", day(2025, time.January, 13)},
+		{"+3d", day(2025, time.January, 9)},
+		{"+2w", day(2025, time.January, 20)},
+		{"+4h", now.Add(4 * time.Hour)},
+		{"wed", day(2025, time.January, 8)},
+		{"fri", day(2025, time.January, 10)},
+		{"sunday", day(2025, time.January, 12)},
+		// A weekday always means the next one, so today's weekday is a week out
+		{"mon", day(2025, time.January, 13)},
+		{"next mon", day(2025, time.January, 13)},
+		{"2025-01-02", day(2025, time.January, 2)},
+	}
+	for _, tt := range tests {
+		got, err := ParseRelative(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseRelative(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseRelative(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseRelativeErrors(t *testing.T) {
+	for _, input := range []string{"", "someday", "+d", "+0d", "+3x", "2025-13-01", "next"} {
+		if got, err := ParseRelative(input, now); err == nil {
+			t.Errorf("ParseRelative(%q) = %v, want an error", input, got)
+		}
+	}
+}
+
+func TestNextRecurrence(t *testing.T) {
+	jan31 := time.Date(2025, time.January, 31, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule string
+		from time.Time
+		want time.Time
+	}{
+		{"daily", now, day(2025, time.January, 7)},
+		{"weekly", now, day(2025, time.January, 13)},
+		{"monthly", now, day(2025, time.February, 6)},
+		{"yearly", now, day(2026, time.January, 6)},
+		{"Every 3 Days", now, day(2025, time.January, 9)},
+		{"every 1 day", now, day(2025, time.January, 7)},
+		{"every 2 weeks", now, day(2025, time.January, 20)},
+		{"every 3 months", now, day(2025, time.April, 6)},
+		// Months without the day keep to their last day
+		{"monthly", jan31, day(2025, time.February, 28)},
+		{"every 3 months", jan31, day(2025, time.April, 30)},
+		{"yearly", time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC), day(2025, time.February, 28)},
+	}
+	for _, tt := range tests {
+		got, err := NextRecurrence(tt.rule, tt.from)
+		if err != nil {
+			t.Errorf("NextRecurrence(%q) returned error: %v", tt.rule, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("NextRecurrence(%q, %v) = %v, want %v", tt.rule, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestNextRecurrenceErrors(t *testing.T) {
+	for _, rule := range []string{"", "hourly", "every day", "every 0 days", "every -2 weeks", "every 3 fortnights"} {
+		if got, err := NextRecurrence(rule, now); err == nil {
+			t.Errorf("NextRecurrence(%q) = %v, want an error", rule, got)
+		}
+	}
+}
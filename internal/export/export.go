@@ -0,0 +1,141 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+// cardRecord is the serialized form of a card used by the JSON exporter
+type cardRecord struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Tags        []string  `json:"tags"`
+	Column      string    `json:"column,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// JSON writes the given cards as an indented JSON document
+func JSON(w io.Writer, board models.Board, cards []models.Card) error {
+	records := make([]cardRecord, len(cards))
+	for i, c := range cards {
+		tags := c.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		records[i] = cardRecord{
+			Number:      c.Number,
+			Title:       c.Title,
+			Description: c.Description,
+			Tags:        tags,
+			Column:      c.ColumnName,
+			CreatedAt:   c.CreatedAt,
+		}
+	}
+
+	doc := struct {
+		Board string       `json:"board"`
+		Cards []cardRecord `json:"cards"`
+	}{Board: board.Name, Cards: records}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// CSV writes the given cards as CSV with a header row
+func CSV(w io.Writer, cards []models.Card) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"number", "title", "description", "tags", "column", "created_at"}); err != nil {
+		return err
+	}
+	for _, c := range cards {
+		created := ""
+		if !c.CreatedAt.IsZero() {
+			created = c.CreatedAt.Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{
+			strconv.Itoa(c.Number),
+			c.Title,
+			c.Description,
+			strings.Join(c.Tags, ";"),
+			c.ColumnName,
+			created,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Markdown writes the given cards as a markdown checklist grouped under the
+// board name, with closed cards checked off
+func Markdown(w io.Writer, board models.Board, cards []models.Card) error {
+	if _, err := fmt.Fprintf(w, "# %s\n\n", board.Name); err != nil {
+		return err
+	}
+	for _, c := range cards {
+		check := " "
+		if c.ColumnID == "done" {
+			check = "x"
+		}
+		line := fmt.Sprintf("- [%s] #%d %s", check, c.Number, c.Title)
+		if len(c.Tags) > 0 {
+			line += " `" + strings.Join(c.Tags, "` `") + "`"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if c.Description != "" {
+			for _, descLine := range strings.Split(c.Description, "\n") {
+				if _, err := fmt.Fprintf(w, "  %s\n", descLine); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FileName returns a filesystem-friendly file name for exporting a board
+func FileName(board models.Board, ext string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(board.Name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastDash = false
+		case !lastDash && b.Len() > 0:
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "-")
+	if name == "" {
+		name = "board"
+	}
+	return name + "-cards." + ext
+}
+
+// Plain writes one line per card: number, title and tags in brackets
+func Plain(w io.Writer, cards []models.Card) error {
+	for _, c := range cards {
+		line := fmt.Sprintf("#%d %s", c.Number, c.Title)
+		if len(c.Tags) > 0 {
+			line += " [" + strings.Join(c.Tags, ", ") + "]"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/tgienger/stm/internal/fizzy"
+)
+
+// JSONL record types, written in the "_type" field of every line
+const (
+	TypeBoard   = "board"
+	TypeColumn  = "column"
+	TypeCard    = "card"
+	TypeComment = "comment"
+)
+
+// BackupRecord is one line of a JSON-lines backup. Which fields are set depends
+// on Type: boards and columns carry ID and Name, cards and comments carry
+// Number, and everything but boards carries the board it belongs to.
+type BackupRecord struct {
+	Type        string    `json:"_type"`
+	ID          string    `json:"id,omitempty"`
+	BoardID     string    `json:"board_id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Number      int       `json:"number,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	ColumnID    string    `json:"column_id,omitempty"`
+	Closed      bool      `json:"closed,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+}
+
+// AllJSONL writes every board, column, card and comment as one JSON object per
+// line. Each board is fetched and written before the next is loaded, so memory
+// use stays flat however large the account is. A card's line always comes after
+// its board and column lines, and before its comments.
+func AllJSONL(f *fizzy.Fizzy, w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	boards, err := f.ListBoards()
+	if err != nil {
+		return err
+	}
+	for _, b := range boards {
+		if err := enc.Encode(BackupRecord{Type: TypeBoard, ID: b.ID, Name: b.Name, CreatedAt: b.CreatedAt}); err != nil {
+			return err
+		}
+
+		columns, err := f.ListColumns(b.ID)
+		if err != nil {
+			return err
+		}
+		for _, col := range columns {
+			if col.Pseudo {
+				continue
+			}
+			if err := enc.Encode(BackupRecord{Type: TypeColumn, ID: col.ID, BoardID: b.ID, Name: col.Name}); err != nil {
+				return err
+			}
+		}
+
+		cards, err := f.ListCardsByColumn(b.ID, "", true)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			rec := BackupRecord{
+				Type:        TypeCard,
+				BoardID:     b.ID,
+				Number:      c.Number,
+				Title:       c.Title,
+				Description: c.Description,
+				Tags:        c.Tags,
+				ColumnID:    c.ColumnID,
+				Closed:      c.ColumnID == "done",
+				CreatedAt:   c.CreatedAt,
+			}
+			if err := enc.Encode(rec); err != nil {
+				return err
+			}
+
+			comments, err := f.ListComments(c.Number)
+			if err != nil {
+				return err
+			}
+			for _, cm := range comments {
+				rec := BackupRecord{
+					Type:      TypeComment,
+					BoardID:   b.ID,
+					Number:    c.Number,
+					Author:    cm.Author,
+					Body:      cm.Body,
+					CreatedAt: cm.CreatedAt,
+				}
+				if err := enc.Encode(rec); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -2,14 +2,20 @@ package fizzy
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/tgienger/stm/internal/models"
 )
 
+// ErrCardNotFound is returned when no board contains a card with the requested number
+var ErrCardNotFound = errors.New("card not found")
+
 // Fizzy wraps calls to the fizzy CLI
 type Fizzy struct {
 	binPath string
@@ -35,13 +41,16 @@ type jsonEnvelope struct {
 }
 
 func (f *Fizzy) run(args ...string) (json.RawMessage, error) {
+	slog.Debug("fizzy", "args", args)
 	out, err := exec.Command(f.binPath, args...).CombinedOutput()
 	if err != nil {
+		slog.Error("fizzy command failed", "args", args, "err", err, "output", string(out))
 		return nil, fmt.Errorf("fizzy %s: %w\n%s", strings.Join(args, " "), err, out)
 	}
 
 	var env jsonEnvelope
 	if err := json.Unmarshal(out, &env); err != nil {
+		slog.Error("fizzy response unparseable", "args", args, "err", err)
 		return nil, fmt.Errorf("fizzy: failed to parse response: %w", err)
 	}
 	if !env.Success {
@@ -49,6 +58,7 @@ func (f *Fizzy) run(args ...string) (json.RawMessage, error) {
 		if env.Error != nil {
 			msg = env.Error.Message
 		}
+		slog.Error("fizzy returned error", "args", args, "message", msg)
 		return nil, fmt.Errorf("fizzy: %s", msg)
 	}
 	return env.Data, nil
@@ -104,6 +114,75 @@ func (f *Fizzy) CreateBoard(name string) (*models.Board, error) {
 	}, nil
 }
 
+// EnsureBoard returns the board with the given name, matched case-insensitively,
+// creating it if no such board exists.
+func (f *Fizzy) EnsureBoard(name string) (*models.Board, error) {
+	boards, err := f.ListBoards()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range boards {
+		if strings.EqualFold(b.Name, name) {
+			return &b, nil
+		}
+	}
+	return f.CreateBoard(name)
+}
+
+// CloneBoard creates a new board with the source board's columns and a copy of each
+// of its cards, keeping their tags, column and closed state. Comments are not copied.
+// If copying fails partway, the partially filled board is returned with the error.
+func (f *Fizzy) CloneBoard(sourceID, name string) (*models.Board, error) {
+	columns, err := f.ListColumns(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := f.listCards(sourceID, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := f.CreateBoard(name)
+	if err != nil {
+		return nil, err
+	}
+
+	columnIDs := make(map[string]string, len(columns))
+	for _, col := range columns {
+		if col.Pseudo {
+			continue
+		}
+		created, err := f.CreateColumn(board.ID, col.Name)
+		if err != nil {
+			return board, err
+		}
+		columnIDs[col.ID] = created.ID
+	}
+
+	for _, c := range cards {
+		created, err := f.CreateCard(board.ID, c.Title, c.Description)
+		if err != nil {
+			return board, err
+		}
+		for _, tag := range c.Tags {
+			if _, err := f.AddTag(created, tag); err != nil {
+				return board, err
+			}
+		}
+		if colID, ok := columnIDs[c.ColumnID]; ok {
+			if err := f.MoveCardToColumn(created.Number, colID); err != nil {
+				return board, err
+			}
+		}
+		if c.ColumnID == "done" {
+			if err := f.CloseCard(created.Number); err != nil {
+				return board, err
+			}
+		}
+	}
+	return board, nil
+}
+
 func (f *Fizzy) DeleteBoard(id string) error {
 	_, err := f.run("board", "delete", id)
 	return err
@@ -115,6 +194,42 @@ func (f *Fizzy) ListCards(boardID string) ([]models.Card, error) {
 	return f.listCards(boardID, "", false)
 }
 
+// CountCompleted returns how many of a board's cards are closed, out of all its cards.
+func (f *Fizzy) CountCompleted(boardID string) (closed, total int, err error) {
+	cards, err := f.listCards(boardID, "", true)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, c := range cards {
+		if c.ColumnID == "done" {
+			closed++
+		}
+	}
+	return closed, len(cards), nil
+}
+
+// FindCard looks up a card by number across all boards, including closed cards.
+// It returns ErrCardNotFound if no board has the card.
+func (f *Fizzy) FindCard(number int) (*models.Board, *models.Card, error) {
+	boards, err := f.ListBoards()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, b := range boards {
+		cards, err := f.listCards(b.ID, "", true)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range cards {
+			if c.Number == number {
+				return &b, &c, nil
+			}
+		}
+	}
+	return nil, nil, ErrCardNotFound
+}
+
 // ListCardsByColumn returns cards in a specific column (works with both real and pseudo column IDs).
 func (f *Fizzy) ListCardsByColumn(boardID, columnID string, includeClosed bool) ([]models.Card, error) {
 	return f.listCards(boardID, columnID, includeClosed)
@@ -245,6 +360,27 @@ func (f *Fizzy) DeleteCard(number int) error {
 	return err
 }
 
+// DeleteClosedCards deletes the closed cards on a board, except those keep
+// returns true for, and returns how many were removed. keep may be nil.
+func (f *Fizzy) DeleteClosedCards(boardID string, keep func(models.Card) bool) (int, error) {
+	cards, err := f.listCards(boardID, "", true)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, c := range cards {
+		if c.ColumnID != "done" || (keep != nil && keep(c)) {
+			continue
+		}
+		if err := f.DeleteCard(c.Number); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
 // TagCard toggles a tag on a card. If the card has the tag, it removes it; otherwise adds it.
 func (f *Fizzy) TagCard(cardNumber int, tagName string, hasTag bool) error {
 	// fizzy card tag is a toggle, so we only call it if we need to change state
@@ -252,12 +388,61 @@ func (f *Fizzy) TagCard(cardNumber int, tagName string, hasTag bool) error {
 	return err
 }
 
+// AddTag gives card the tag unless it already has it, and reports whether it
+// changed. card.Tags is updated to match, so repeated calls are safe.
+func (f *Fizzy) AddTag(card *models.Card, tagName string) (bool, error) {
+	if slices.Contains(card.Tags, tagName) {
+		return false, nil
+	}
+	if err := f.TagCard(card.Number, tagName, false); err != nil {
+		return false, err
+	}
+	card.Tags = append(card.Tags, tagName)
+	return true, nil
+}
+
+// RemoveTag takes the tag off card if it has it, and reports whether it changed.
+// card.Tags is updated to match.
+func (f *Fizzy) RemoveTag(card *models.Card, tagName string) (bool, error) {
+	i := slices.Index(card.Tags, tagName)
+	if i < 0 {
+		return false, nil
+	}
+	if err := f.TagCard(card.Number, tagName, true); err != nil {
+		return false, err
+	}
+	card.Tags = slices.Delete(card.Tags, i, i+1)
+	return true, nil
+}
+
 // MoveCardToColumn moves a card to a specific column
 func (f *Fizzy) MoveCardToColumn(cardNumber int, columnID string) error {
 	_, err := f.run("card", "column", fmt.Sprintf("%d", cardNumber), "--column", columnID)
 	return err
 }
 
+// ActivityByDay counts cards created on a board per day over the last `days` days,
+// keyed by date in YYYY-MM-DD form. Closed cards are included.
+func (f *Fizzy) ActivityByDay(boardID string, days int) (map[string]int, error) {
+	cards, err := f.listCards(boardID, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, now.Location())
+
+	counts := make(map[string]int)
+	for _, c := range cards {
+		created := c.CreatedAt.In(now.Location())
+		if created.Before(start) {
+			continue
+		}
+		counts[created.Format("2006-01-02")]++
+	}
+	return counts, nil
+}
+
 // --- Columns ---
 
 func (f *Fizzy) ListColumns(boardID string) ([]models.Column, error) {
@@ -339,6 +524,79 @@ func (f *Fizzy) ListTags() ([]models.Tag, error) {
 	return tags, nil
 }
 
+// CountCardsByTag returns the number of open cards on a board carrying each tag, keyed by tag title.
+func (f *Fizzy) CountCardsByTag(boardID string) (map[string]int, error) {
+	cards, err := f.listCards(boardID, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, c := range cards {
+		for _, t := range c.Tags {
+			counts[t]++
+		}
+	}
+	return counts, nil
+}
+
+// TagUsageCounts returns the number of open cards carrying each tag across all
+// boards, keyed by tag title.
+func (f *Fizzy) TagUsageCounts() (map[string]int, error) {
+	boards, err := f.ListBoards()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, b := range boards {
+		boardCounts, err := f.CountCardsByTag(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for tag, n := range boardCounts {
+			counts[tag] += n
+		}
+	}
+	return counts, nil
+}
+
+// RetagCards adds toTag to every card carrying fromTag across all boards, optionally
+// removing fromTag from them. It returns how many cards were changed.
+func (f *Fizzy) RetagCards(fromTag, toTag string, removeSource bool) (int, error) {
+	boards, err := f.ListBoards()
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, b := range boards {
+		cards, err := f.listCards(b.ID, "", true)
+		if err != nil {
+			return changed, err
+		}
+		for _, c := range cards {
+			if !slices.Contains(c.Tags, fromTag) {
+				continue
+			}
+			added, err := f.AddTag(&c, toTag)
+			if err != nil {
+				return changed, err
+			}
+			removed := false
+			if removeSource {
+				if removed, err = f.RemoveTag(&c, fromTag); err != nil {
+					return changed, err
+				}
+			}
+			if added || removed {
+				changed++
+			}
+		}
+	}
+	return changed, nil
+}
+
 // --- Comments ---
 
 func (f *Fizzy) ListComments(cardNumber int) ([]models.Comment, error) {
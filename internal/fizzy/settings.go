@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Settings provides local key-value storage for STM app state.
@@ -39,11 +43,32 @@ func NewSettings() (*Settings, error) {
 	return &Settings{path: path, values: values}, nil
 }
 
+// Path returns the location of the settings file.
+func (s *Settings) Path() string {
+	return s.path
+}
+
 // Get retrieves a setting value by key. Returns empty string if not found.
 func (s *Settings) Get(key string) string {
 	return s.values[key]
 }
 
+// WithPrefix returns every setting whose key starts with prefix, keyed by the rest of the key.
+func (s *Settings) WithPrefix(prefix string) map[string]string {
+	values := make(map[string]string)
+	for k, v := range s.values {
+		if rest, ok := strings.CutPrefix(k, prefix); ok {
+			values[rest] = v
+		}
+	}
+	return values
+}
+
+// Bool reports whether a setting is set to "true".
+func (s *Settings) Bool(key string) bool {
+	return s.values[key] == "true"
+}
+
 // Set stores a setting value.
 func (s *Settings) Set(key, value string) error {
 	s.values[key] = value
@@ -53,3 +78,266 @@ func (s *Settings) Set(key, value string) error {
 	}
 	return os.WriteFile(s.path, data, 0644)
 }
+
+func snoozeKey(cardNumber int) string {
+	return "snooze_until:" + strconv.Itoa(cardNumber)
+}
+
+// SnoozeCard hides a card from the default list until the given time.
+func (s *Settings) SnoozeCard(cardNumber int, until time.Time) error {
+	return s.Set(snoozeKey(cardNumber), until.Format(time.RFC3339))
+}
+
+// UnsnoozeCard clears a card's snooze.
+func (s *Settings) UnsnoozeCard(cardNumber int) error {
+	return s.Set(snoozeKey(cardNumber), "")
+}
+
+// SnoozedUntil returns when a card's snooze ends and whether it is still snoozed at now.
+func (s *Settings) SnoozedUntil(cardNumber int, now time.Time) (time.Time, bool) {
+	until, err := time.Parse(time.RFC3339, s.Get(snoozeKey(cardNumber)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, now.Before(until)
+}
+
+func closedAtKey(cardNumber int) string {
+	return "closed_at:" + strconv.Itoa(cardNumber)
+}
+
+// SetClosedAt records when stm closed a card; a zero time clears it. fizzy
+// keeps no close time, so this is the only record of one.
+func (s *Settings) SetClosedAt(cardNumber int, at time.Time) error {
+	value := ""
+	if !at.IsZero() {
+		value = at.Format(time.RFC3339)
+	}
+	return s.Set(closedAtKey(cardNumber), value)
+}
+
+// ClosedAt returns when stm closed a card, and false if it has no record of it.
+func (s *Settings) ClosedAt(cardNumber int) (time.Time, bool) {
+	at, err := time.Parse(time.RFC3339, s.Get(closedAtKey(cardNumber)))
+	return at, err == nil
+}
+
+// ClosedBefore returns the cards stm closed before cutoff that aren't archived yet.
+func (s *Settings) ClosedBefore(cutoff time.Time) []int {
+	var numbers []int
+	for key := range s.WithPrefix("closed_at:") {
+		number, err := strconv.Atoi(key)
+		if err != nil || s.IsArchived(number) {
+			continue
+		}
+		if closedAt, ok := s.ClosedAt(number); ok && closedAt.Before(cutoff) {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// ClosedSince returns the cards stm closed at or after since.
+func (s *Settings) ClosedSince(since time.Time) []int {
+	var numbers []int
+	for key := range s.WithPrefix("closed_at:") {
+		number, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		if closedAt, ok := s.ClosedAt(number); ok && !closedAt.Before(since) {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+func archivedKey(cardNumber int) string {
+	return "archived:" + strconv.Itoa(cardNumber)
+}
+
+// ArchiveCard hides a closed card from every list except the archive.
+func (s *Settings) ArchiveCard(cardNumber int, at time.Time) error {
+	return s.Set(archivedKey(cardNumber), at.Format(time.RFC3339))
+}
+
+// UnarchiveCard restores an archived card.
+func (s *Settings) UnarchiveCard(cardNumber int) error {
+	return s.Set(archivedKey(cardNumber), "")
+}
+
+// IsArchived reports whether a card has been archived.
+func (s *Settings) IsArchived(cardNumber int) bool {
+	return s.Get(archivedKey(cardNumber)) != ""
+}
+
+func shelvedKey(cardNumber int) string {
+	return "shelved:" + strconv.Itoa(cardNumber)
+}
+
+// ShelveCard hides an open card from the list without closing it.
+func (s *Settings) ShelveCard(cardNumber int, at time.Time) error {
+	return s.Set(shelvedKey(cardNumber), at.Format(time.RFC3339))
+}
+
+// UnshelveCard returns a shelved card to the list.
+func (s *Settings) UnshelveCard(cardNumber int) error {
+	return s.Set(shelvedKey(cardNumber), "")
+}
+
+// IsShelved reports whether a card has been shelved.
+func (s *Settings) IsShelved(cardNumber int) bool {
+	return s.Get(shelvedKey(cardNumber)) != ""
+}
+
+func waitingOnKey(cardNumber int) string {
+	return "waiting_on:" + strconv.Itoa(cardNumber)
+}
+
+func followUpKey(cardNumber int) string {
+	return "follow_up:" + strconv.Itoa(cardNumber)
+}
+
+// SetWaiting records who or what a card is waiting on and when to follow up.
+// An empty who clears both; a zero followUp stores no follow-up date.
+func (s *Settings) SetWaiting(cardNumber int, who string, followUp time.Time) error {
+	if who == "" {
+		followUp = time.Time{}
+	}
+	date := ""
+	if !followUp.IsZero() {
+		date = followUp.Format(time.RFC3339)
+	}
+	if err := s.Set(waitingOnKey(cardNumber), who); err != nil {
+		return err
+	}
+	return s.Set(followUpKey(cardNumber), date)
+}
+
+// Waiting returns who a card is waiting on and its follow-up date, which is zero if unset.
+func (s *Settings) Waiting(cardNumber int) (string, time.Time) {
+	followUp, _ := time.Parse(time.RFC3339, s.Get(followUpKey(cardNumber)))
+	return s.Get(waitingOnKey(cardNumber)), followUp
+}
+
+func externalRefKey(cardNumber int) string {
+	return "external_ref:" + strconv.Itoa(cardNumber)
+}
+
+// SetExternalRef links a card to an outside reference such as "GH-123" or a URL.
+func (s *Settings) SetExternalRef(cardNumber int, ref string) error {
+	return s.Set(externalRefKey(cardNumber), ref)
+}
+
+// ExternalRef returns a card's outside reference, or "" if it has none.
+func (s *Settings) ExternalRef(cardNumber int) string {
+	return s.Get(externalRefKey(cardNumber))
+}
+
+func recurrenceKey(cardNumber int) string {
+	return "repeat:" + strconv.Itoa(cardNumber)
+}
+
+// SetRecurrence sets how often a card repeats, such as "weekly"; "" stops it repeating.
+func (s *Settings) SetRecurrence(cardNumber int, rule string) error {
+	return s.Set(recurrenceKey(cardNumber), rule)
+}
+
+// Recurrence returns how often a card repeats, or "" if it doesn't.
+func (s *Settings) Recurrence(cardNumber int) string {
+	return s.Get(recurrenceKey(cardNumber))
+}
+
+func estimateKey(cardNumber int) string {
+	return "estimate:" + strconv.Itoa(cardNumber)
+}
+
+// SetEstimate records a rough estimate of a card's effort in minutes; 0 clears it.
+func (s *Settings) SetEstimate(cardNumber int, minutes int) error {
+	value := ""
+	if minutes > 0 {
+		value = strconv.Itoa(minutes)
+	}
+	return s.Set(estimateKey(cardNumber), value)
+}
+
+// Estimate returns a card's estimated effort in minutes, or 0 if it has none.
+func (s *Settings) Estimate(cardNumber int) int {
+	minutes, _ := strconv.Atoi(s.Get(estimateKey(cardNumber)))
+	return minutes
+}
+
+func blockedByKey(cardNumber int) string {
+	return "blocked_by:" + strconv.Itoa(cardNumber)
+}
+
+// SetBlockedBy records the cards that must be closed before a card can be
+// worked on; an empty list clears it.
+func (s *Settings) SetBlockedBy(cardNumber int, blockers []int) error {
+	numbers := make([]string, len(blockers))
+	for i, n := range blockers {
+		numbers[i] = strconv.Itoa(n)
+	}
+	return s.Set(blockedByKey(cardNumber), strings.Join(numbers, ","))
+}
+
+// BlockedBy returns the cards a card is blocked by, in the order they were given.
+func (s *Settings) BlockedBy(cardNumber int) []int {
+	return parseCardNumbers(s.Get(blockedByKey(cardNumber)))
+}
+
+// Blocks returns the cards that list cardNumber among their blockers, lowest first.
+func (s *Settings) Blocks(cardNumber int) []int {
+	var blocked []int
+	for key, value := range s.WithPrefix("blocked_by:") {
+		n, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		for _, b := range parseCardNumbers(value) {
+			if b == cardNumber {
+				blocked = append(blocked, n)
+				break
+			}
+		}
+	}
+	sort.Ints(blocked)
+	return blocked
+}
+
+func parseCardNumbers(value string) []int {
+	var numbers []int
+	for _, field := range strings.Split(value, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// TagColors parses the tag_colors setting, comma-separated tag=color pairs such
+// as "blocked=#f7768e,waiting=#e0af68", into a map keyed by lowercased tag name.
+func (s *Settings) TagColors() map[string]string {
+	rules := make(map[string]string)
+	for _, pair := range strings.Split(s.Get("tag_colors"), ",") {
+		tag, color, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		rules[strings.ToLower(strings.TrimSpace(tag))] = strings.TrimSpace(color)
+	}
+	return rules
+}
+
+// SetTagColors replaces the tag_colors setting with the given tag to color map.
+func (s *Settings) SetTagColors(colors map[string]string) error {
+	pairs := make([]string, 0, len(colors))
+	for tag, color := range colors {
+		if tag == "" || color == "" {
+			continue
+		}
+		pairs = append(pairs, strings.ToLower(tag)+"="+color)
+	}
+	sort.Strings(pairs)
+	return s.Set("tag_colors", strings.Join(pairs, ","))
+}
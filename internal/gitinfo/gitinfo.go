@@ -0,0 +1,34 @@
+package gitinfo
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotRepo is returned when the working directory is not inside a git repository
+var ErrNotRepo = errors.New("not inside a git repository")
+
+// Head returns the current branch name and short commit hash of the git
+// repository containing the working directory.
+func Head() (branch, commit string, err error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", "", err
+	}
+	if out, err := exec.Command("git", "rev-parse", "--is-inside-work-tree").Output(); err != nil || strings.TrimSpace(string(out)) != "true" {
+		return "", "", ErrNotRepo
+	}
+
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", "", err
+	}
+	branch = strings.TrimSpace(string(out))
+
+	out, err = exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		// A fresh repository has a branch but no commits yet
+		return branch, "", nil
+	}
+	return branch, strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,159 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tgienger/stm/internal/export"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+)
+
+// ImportJSONL restores a backup written by export.AllJSONL, decoding one record
+// at a time so large backups never sit in memory whole. Boards are matched by
+// name and created when missing, as are their columns. Cards get new numbers,
+// and comments are re-posted under the current user.
+//
+// Like ImportTaskwarrior, a failure part-way leaves what was created so far.
+// Because records are applied as they are read, that includes a malformed line
+// further down the file.
+func ImportJSONL(f *fizzy.Fizzy, r io.Reader, opts Options) (Result, error) {
+	var res Result
+
+	existing, err := f.ListBoards()
+	if err != nil {
+		return res, err
+	}
+	byName := make(map[string]models.Board, len(existing))
+	for _, b := range existing {
+		byName[strings.ToLower(b.Name)] = b
+	}
+
+	boards := make(map[string]models.Board) // backup board ID -> target board
+	columns := make(map[string]string)      // backup column ID -> target column ID
+	cards := make(map[int]int)              // backup card number -> new card number
+
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var rec export.BackupRecord
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			return res, nil
+		} else if err != nil {
+			return res, fmt.Errorf("jsonl: record %d: %w", line, err)
+		}
+
+		switch rec.Type {
+		case export.TypeBoard:
+			board, ok := byName[strings.ToLower(rec.Name)]
+			if !ok {
+				if opts.DryRun {
+					opts.logf("create board %q", rec.Name)
+					board = models.Board{Name: rec.Name}
+				} else {
+					created, err := f.CreateBoard(rec.Name)
+					if err != nil {
+						return res, err
+					}
+					board = *created
+				}
+				byName[strings.ToLower(rec.Name)] = board
+				res.Boards++
+			}
+			boards[rec.ID] = board
+
+		case export.TypeColumn:
+			board, ok := boards[rec.BoardID]
+			if !ok {
+				return res, fmt.Errorf("jsonl: record %d: column before its board", line)
+			}
+			colID, err := ensureColumn(f, board, rec.Name, opts)
+			if err != nil {
+				return res, err
+			}
+			columns[rec.ID] = colID
+
+		case export.TypeCard:
+			board, ok := boards[rec.BoardID]
+			if !ok {
+				return res, fmt.Errorf("jsonl: record %d: card before its board", line)
+			}
+			if opts.DryRun {
+				opts.logf("create card %q on %q", rec.Title, board.Name)
+				cards[rec.Number] = rec.Number
+				res.Cards++
+				if rec.Closed {
+					res.Closed++
+				}
+				continue
+			}
+
+			card, err := f.CreateCard(board.ID, rec.Title, rec.Description)
+			if err != nil {
+				return res, err
+			}
+			cards[rec.Number] = card.Number
+			res.Cards++
+
+			for _, tag := range rec.Tags {
+				if _, err := f.AddTag(card, tag); err != nil {
+					return res, err
+				}
+			}
+			if colID, ok := columns[rec.ColumnID]; ok {
+				if err := f.MoveCardToColumn(card.Number, colID); err != nil {
+					return res, err
+				}
+			}
+			if rec.Closed {
+				if err := f.CloseCard(card.Number); err != nil {
+					return res, err
+				}
+				res.Closed++
+			}
+
+		case export.TypeComment:
+			number, ok := cards[rec.Number]
+			if !ok {
+				return res, fmt.Errorf("jsonl: record %d: comment before its card", line)
+			}
+			if !opts.DryRun {
+				if _, err := f.CreateComment(number, rec.Body); err != nil {
+					return res, err
+				}
+			}
+			res.Comments++
+
+		default:
+			opts.logf("skip record %d of unknown type %q", line, rec.Type)
+			res.Skipped++
+		}
+	}
+}
+
+// ensureColumn returns the ID of the board's column called name, creating it if needed.
+func ensureColumn(f *fizzy.Fizzy, board models.Board, name string, opts Options) (string, error) {
+	// A board planned by a dry run has no ID yet, and so no columns
+	if board.ID != "" {
+		existing, err := f.ListColumns(board.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, col := range existing {
+			if !col.Pseudo && strings.EqualFold(col.Name, name) {
+				return col.ID, nil
+			}
+		}
+	}
+	if opts.DryRun {
+		opts.logf("create column %q on %q", name, board.Name)
+		return "", nil
+	}
+	created, err := f.CreateColumn(board.ID, name)
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
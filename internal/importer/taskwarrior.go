@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+)
+
+// DefaultBoard receives tasks that have no project
+const DefaultBoard = "Imported"
+
+// Options controls how an import runs
+type Options struct {
+	// DryRun reports what would be created without writing anything to fizzy
+	DryRun bool
+	// Log receives a line per skipped task and, with DryRun, per planned change
+	Log io.Writer
+}
+
+func (o Options) logf(format string, args ...any) {
+	if o.Log != nil {
+		fmt.Fprintf(o.Log, format+"\n", args...)
+	}
+}
+
+// Result summarizes what an import created
+type Result struct {
+	Boards   int
+	Cards    int
+	Closed   int
+	Comments int
+	Skipped  int
+}
+
+// twTask is a Taskwarrior export record. Fields without a fizzy equivalent are
+// kept in Extra and written into the card description.
+type twTask struct {
+	Description string   `json:"description"`
+	Project     string   `json:"project"`
+	Priority    string   `json:"priority"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	Annotations []struct {
+		Entry       string `json:"entry"`
+		Description string `json:"description"`
+	} `json:"annotations"`
+	Extra map[string]any `json:"-"`
+}
+
+// twKnownFields are consumed directly and never copied into the description
+var twKnownFields = map[string]bool{
+	"id": true, "description": true, "project": true, "priority": true,
+	"status": true, "tags": true, "annotations": true, "urgency": true,
+}
+
+var twPriorityNames = map[string]string{"H": "high", "M": "medium", "L": "low"}
+
+// parseTaskwarrior reads a Taskwarrior JSON export, accepting either a JSON array
+// (`task export`) or one JSON object per line.
+func parseTaskwarrior(r io.Reader) ([]twTask, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return nil, fmt.Errorf("taskwarrior: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		for dec.More() {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("taskwarrior: %w", err)
+			}
+			raws = append(raws, raw)
+		}
+	}
+
+	tasks := make([]twTask, 0, len(raws))
+	for i, raw := range raws {
+		var t twTask
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return nil, fmt.Errorf("taskwarrior: record %d: %w", i+1, err)
+		}
+		if err := json.Unmarshal(raw, &t.Extra); err != nil {
+			return nil, fmt.Errorf("taskwarrior: record %d: %w", i+1, err)
+		}
+		if strings.TrimSpace(t.Description) == "" {
+			return nil, fmt.Errorf("taskwarrior: record %d has no description", i+1)
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// dryRunSummary describes the tags, comments and state a card would be created with
+func (t twTask) dryRunSummary() string {
+	var parts []string
+	if len(t.Tags) > 0 {
+		parts = append(parts, "tags: "+strings.Join(t.Tags, ", "))
+	}
+	if len(t.Annotations) > 0 {
+		parts = append(parts, fmt.Sprintf("%d comments", len(t.Annotations)))
+	}
+	if t.Status == "completed" {
+		parts = append(parts, "closed")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
+// cardDescription builds the card description from the fields fizzy can't store
+func (t twTask) cardDescription() string {
+	var lines []string
+	if name, ok := twPriorityNames[strings.ToUpper(t.Priority)]; ok {
+		lines = append(lines, "priority: "+name)
+	}
+
+	keys := make([]string, 0, len(t.Extra))
+	for k := range t.Extra {
+		if !twKnownFields[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %v", k, t.Extra[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ImportTaskwarrior creates boards, cards, tags and comments from a Taskwarrior
+// export. Projects map to boards (created when missing), annotations become
+// comments and completed tasks are closed; deleted tasks are skipped.
+//
+// The whole export is parsed and validated before anything is written. fizzy
+// has no transactions, so a failure part-way leaves the cards created so far and
+// the returned Result reports them. With opts.DryRun the same Result is computed
+// and each change is described on opts.Log instead of being applied.
+func ImportTaskwarrior(f *fizzy.Fizzy, r io.Reader, opts Options) (Result, error) {
+	var res Result
+
+	tasks, err := parseTaskwarrior(r)
+	if err != nil {
+		return res, err
+	}
+
+	existing, err := f.ListBoards()
+	if err != nil {
+		return res, err
+	}
+	boards := make(map[string]models.Board, len(existing))
+	for _, b := range existing {
+		boards[strings.ToLower(b.Name)] = b
+	}
+
+	for _, t := range tasks {
+		if t.Status == "deleted" {
+			opts.logf("skip deleted task %q", t.Description)
+			res.Skipped++
+			continue
+		}
+
+		boardName := strings.TrimSpace(t.Project)
+		if boardName == "" {
+			boardName = DefaultBoard
+		}
+		board, ok := boards[strings.ToLower(boardName)]
+		if !ok {
+			if opts.DryRun {
+				opts.logf("create board %q", boardName)
+				board = models.Board{Name: boardName}
+			} else {
+				created, err := f.CreateBoard(boardName)
+				if err != nil {
+					return res, err
+				}
+				board = *created
+			}
+			boards[strings.ToLower(boardName)] = board
+			res.Boards++
+		}
+
+		if opts.DryRun {
+			opts.logf("create card %q on %q%s", t.Description, board.Name, t.dryRunSummary())
+			res.Cards++
+			res.Comments += len(t.Annotations)
+			if t.Status == "completed" {
+				res.Closed++
+			}
+			continue
+		}
+
+		card, err := f.CreateCard(board.ID, t.Description, t.cardDescription())
+		if err != nil {
+			return res, err
+		}
+		res.Cards++
+
+		for _, tag := range t.Tags {
+			if _, err := f.AddTag(card, tag); err != nil {
+				return res, err
+			}
+		}
+
+		for _, a := range t.Annotations {
+			if _, err := f.CreateComment(card.Number, a.Description); err != nil {
+				return res, err
+			}
+			res.Comments++
+		}
+
+		if t.Status == "completed" {
+			if err := f.CloseCard(card.Number); err != nil {
+				return res, err
+			}
+			res.Closed++
+		}
+	}
+	return res, nil
+}
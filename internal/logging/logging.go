@@ -0,0 +1,25 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+)
+
+// Setup routes the default slog logger to the file at path, or discards all
+// logging when path is empty. Logs never go to stdout or stderr, which the TUI
+// owns. The returned function closes the log file.
+func Setup(path string) (func() error, error) {
+	if path == "" {
+		slog.SetDefault(slog.New(slog.DiscardHandler))
+		return func() error { return nil }, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		slog.SetDefault(slog.New(slog.DiscardHandler))
+		return nil, err
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	return f.Close, nil
+}
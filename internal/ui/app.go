@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"log/slog"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tgienger/stm/internal/fizzy"
 	"github.com/tgienger/stm/internal/models"
@@ -12,14 +15,27 @@ type View int
 const (
 	ViewBoards View = iota
 	ViewCards
+	ViewActivity
 )
 
+func (v View) String() string {
+	switch v {
+	case ViewCards:
+		return "cards"
+	case ViewActivity:
+		return "activity"
+	}
+	return "boards"
+}
+
 type App struct {
 	fizzy       *fizzy.Fizzy
 	settings    *fizzy.Settings
 	currentView View
 	boardList   *views.BoardListView
 	cardList    *views.CardListView
+	activity    *views.ActivityView
+	overlay     tea.Model // jump, capture, command or inbox prompt drawn over the current view
 	width       int
 	height      int
 }
@@ -34,12 +50,12 @@ func NewApp(f *fizzy.Fizzy, s *fizzy.Settings) *App {
 		fizzy:       f,
 		settings:    s,
 		currentView: ViewBoards,
-		boardList:   views.NewBoardListView(f),
+		boardList:   views.NewBoardListView(f, s),
 	}
 }
 
 func (a *App) Init() tea.Cmd {
-	return a.loadInitialBoards
+	return tea.Batch(a.loadInitialBoards, views.AutoArchive(a.fizzy, a.settings), views.CheckInbox(a.fizzy, a.settings))
 }
 
 func (a *App) loadInitialBoards() tea.Msg {
@@ -48,9 +64,14 @@ func (a *App) loadInitialBoards() tea.Msg {
 }
 
 func (a *App) openBoard(board models.Board) tea.Cmd {
+	slog.Debug("view transition", "to", "cards", "board", board.Name)
 	a.currentView = ViewCards
 	a.cardList = views.NewCardListView(a.fizzy, a.settings, board)
 
+	// Remember the board we came from so the card view can bounce back to it
+	if last := a.settings.Get("last_board_id"); last != "" && last != board.ID {
+		_ = a.settings.Set(views.PreviousBoardSettingKey, last)
+	}
 	_ = a.settings.Set("last_board_id", board.ID)
 
 	return tea.Batch(
@@ -63,6 +84,20 @@ func (a *App) openBoard(board models.Board) tea.Cmd {
 
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		slog.Debug("key", "key", msg.String(), "view", a.currentView)
+		// No text widget binds ctrl+o, so capture works from anywhere, even mid-edit
+		if msg.String() == "ctrl+o" && a.overlay == nil {
+			return a, a.openOverlay(views.NewCaptureView(a.fizzy, a.settings))
+		}
+		// Views offer no commands while taking text input, which leaves ctrl+p
+		// to the textarea there.
+		if msg.String() == "ctrl+p" && a.overlay == nil {
+			if commands := a.commands(); len(commands) > 0 {
+				return a, a.openOverlay(views.NewPaletteView(commands))
+			}
+		}
+
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
@@ -70,29 +105,83 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case initialBoardsLoadedMsg:
 		if msg.err != nil {
+			slog.Error("loading boards", "err", msg.err)
 			return a, nil
 		}
 
-		a.boardList.SetBoards(msg.boards)
-
-		lastBoardID := a.settings.Get("last_board_id")
-		if lastBoardID == "" {
-			return a, nil
+		progress := a.boardList.SetBoards(msg.boards)
+		if cmd := a.openStartupBoard(msg.boards); cmd != nil {
+			// Going back to the board list reloads it, progress included
+			return a, cmd
 		}
+		return a, progress
 
-		for _, board := range msg.boards {
-			if board.ID == lastBoardID {
-				return a, a.openBoard(board)
-			}
+	case views.AutoArchiveChecked:
+		status := msg.Apply(a.settings)
+		a.boardList.SetStatus(status)
+		if a.cardList != nil {
+			a.cardList.SetStatus(status)
 		}
-
-		_ = a.settings.Set("last_board_id", "")
 		return a, nil
 
 	case views.SelectedBoard:
 		return a, a.openBoard(msg.Board)
 
+	case views.StartJump:
+		return a, a.openOverlay(views.NewJumpView(a.fizzy))
+
+	case views.InboxNudge:
+		if a.overlay == nil {
+			return a, a.openOverlay(views.NewInboxNudgeView(msg))
+		}
+		return a, nil
+
+	case views.TriageInbox:
+		a.overlay = nil
+		cmd := a.openBoard(msg.Board)
+		a.cardList.ShowUntagged()
+		return a, cmd
+
+	case views.CancelJump, views.CancelCapture, views.CancelPalette, views.CancelInboxNudge:
+		a.overlay = nil
+		return a, nil
+
+	case views.RunCommand:
+		a.overlay = nil
+		return a.Update(msg.Key)
+
+	case views.CaptureDone:
+		slog.Debug("captured card", "card", msg.Card.Number, "board", msg.Board.Name)
+		a.overlay = nil
+
+	case views.JumpToCard:
+		slog.Debug("jump to card", "card", msg.Card.Number, "board", msg.Board.Name)
+		a.overlay = nil
+		cmd := a.openBoard(msg.Board)
+		a.cardList.OpenCardOnLoad(msg.Card)
+		return a, cmd
+
+	case views.ShowActivity:
+		slog.Debug("view transition", "to", "activity", "board", msg.Board.Name)
+		a.currentView = ViewActivity
+		a.activity = views.NewActivityView(a.fizzy, msg.Board)
+		return a, tea.Batch(
+			a.activity.Init(),
+			func() tea.Msg {
+				return tea.WindowSizeMsg{Width: a.width, Height: a.height}
+			},
+		)
+
+	case views.BackToCards:
+		slog.Debug("view transition", "to", "cards")
+		a.currentView = ViewCards
+		a.activity = nil
+		return a, func() tea.Msg {
+			return tea.WindowSizeMsg{Width: a.width, Height: a.height}
+		}
+
 	case views.BackToBoards:
+		slog.Debug("view transition", "to", "boards")
 		a.currentView = ViewBoards
 		return a, tea.Batch(
 			a.boardList.Init(),
@@ -102,23 +191,110 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 	}
 
+	// An overlay prompt takes all key input, while other messages still reach
+	// the view underneath.
+	var overlayCmd tea.Cmd
+	if a.overlay != nil {
+		_, overlayCmd = a.overlay.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return a, overlayCmd
+		}
+	}
+
 	var cmd tea.Cmd
 	switch a.currentView {
 	case ViewBoards:
 		_, cmd = a.boardList.Update(msg)
 	case ViewCards:
 		_, cmd = a.cardList.Update(msg)
+	case ViewActivity:
+		_, cmd = a.activity.Update(msg)
 	}
 
-	return a, cmd
+	return a, tea.Batch(overlayCmd, cmd)
+}
+
+// openStartupBoard opens the board the user starts on, if any.
+func (a *App) openStartupBoard(boards []models.Board) tea.Cmd {
+	// default_board picks the startup board: a board name, "none" for the
+	// board list, or "last" (the default) to reopen the last board.
+	// restore_last_board=false turns the "last" behavior off on its own;
+	// last_board_id is still kept up to date for previous-board switching.
+	switch defaultBoard := strings.TrimSpace(a.settings.Get("default_board")); strings.ToLower(defaultBoard) {
+	case "none":
+		return nil
+	case "", "last":
+		if strings.EqualFold(a.settings.Get("restore_last_board"), "false") {
+			return nil
+		}
+	default:
+		for _, board := range boards {
+			if strings.EqualFold(board.Name, defaultBoard) {
+				return a.openBoard(board)
+			}
+		}
+		slog.Warn("default board not found", "name", defaultBoard)
+		return nil
+	}
+
+	lastBoardID := a.settings.Get("last_board_id")
+	if lastBoardID == "" {
+		return nil
+	}
+
+	for _, board := range boards {
+		if board.ID == lastBoardID {
+			return a.openBoard(board)
+		}
+	}
+
+	_ = a.settings.Set("last_board_id", "")
+	return nil
+}
+
+// commands gathers the command palette entries for the current view, followed
+// by the app-wide ones. It is empty while the view is busy.
+func (a *App) commands() []views.Command {
+	var commander views.Commander
+	switch a.currentView {
+	case ViewBoards:
+		commander = a.boardList
+	case ViewCards:
+		commander = a.cardList
+	}
+	if commander == nil {
+		return nil
+	}
+	commands := commander.Commands()
+	if len(commands) == 0 {
+		return nil
+	}
+	return append(commands,
+		views.Command{Title: "Quick capture to inbox", Key: tea.KeyMsg{Type: tea.KeyCtrlO}},
+		views.Command{Title: "Quit", Key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}},
+	)
+}
+
+func (a *App) openOverlay(overlay tea.Model) tea.Cmd {
+	a.overlay = overlay
+	a.overlay.Update(tea.WindowSizeMsg{Width: a.width, Height: a.height})
+	return a.overlay.Init()
 }
 
 func (a *App) View() string {
+	if a.overlay != nil {
+		return a.overlay.View()
+	}
+
 	switch a.currentView {
 	case ViewCards:
 		if a.cardList != nil {
 			return a.cardList.View()
 		}
+	case ViewActivity:
+		if a.activity != nil {
+			return a.activity.View()
+		}
 	}
 	return a.boardList.View()
 }
@@ -23,6 +23,9 @@ type KeyMap struct {
 	Search key.Binding
 	Filter key.Binding
 	Help   key.Binding
+
+	// QuickTag toggles the tag named by the quick_tag setting on the selected card
+	QuickTag key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -84,5 +87,9 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
 		),
+		QuickTag: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "toggle quick tag"),
+		),
 	}
 }
@@ -0,0 +1,87 @@
+package styles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themes holds every theme SetTheme can switch to, keyed by lowercased name.
+var themes = map[string]Theme{
+	"tokyo night": TokyoNight,
+}
+
+// RegisterTheme makes a theme available to SetTheme under its name, replacing
+// any theme already registered with that name.
+func RegisterTheme(t Theme) {
+	themes[strings.ToLower(t.Name)] = t
+}
+
+// SetTheme makes the named theme current. Styles built afterwards use it.
+func SetTheme(name string) error {
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (have %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	Current = t
+	return nil
+}
+
+// ThemeNames lists the registered themes in alphabetical order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeFromColors builds a theme from hex colors keyed by field name (primary,
+// foreground_dim, border_focus, ...). Fields that are missing or not valid hex
+// keep the value from base; their keys are returned in invalid when malformed.
+func ThemeFromColors(name string, base Theme, colors map[string]string) (t Theme, invalid []string) {
+	t = base
+	t.Name = name
+
+	fields := map[string]*lipgloss.Color{
+		"background":     &t.Background,
+		"foreground":     &t.Foreground,
+		"foreground_dim": &t.ForegroundDim,
+		"primary":        &t.Primary,
+		"secondary":      &t.Secondary,
+		"accent":         &t.Accent,
+		"success":        &t.Success,
+		"warning":        &t.Warning,
+		"error":          &t.Error,
+		"info":           &t.Info,
+		"border":         &t.Border,
+		"border_focus":   &t.BorderFocus,
+		"selection":      &t.Selection,
+		"cursor":         &t.Cursor,
+	}
+	for key, value := range colors {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok || !isHex(value) {
+			invalid = append(invalid, key)
+			continue
+		}
+		*field = lipgloss.Color(value)
+	}
+	sort.Strings(invalid)
+	return t, invalid
+}
+
+func isHex(s string) bool {
+	if len(s) != 4 && len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
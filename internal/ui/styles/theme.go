@@ -1,6 +1,8 @@
 package styles
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -68,6 +70,17 @@ func ContentWidth(terminalWidth int) int {
 	return terminalWidth
 }
 
+// RowColorForTags returns the color configured for the first of tags that has a
+// rule, matching tag names case-insensitively. ok is false when no rule applies.
+func RowColorForTags(tags []string, rules map[string]string) (color lipgloss.Color, ok bool) {
+	for _, t := range tags {
+		if c, found := rules[strings.ToLower(t)]; found && c != "" {
+			return lipgloss.Color(c), true
+		}
+	}
+	return "", false
+}
+
 // CenterView wraps content and centers it horizontally if terminal is wider than MaxWidth
 func CenterView(content string, terminalWidth, terminalHeight int) string {
 	if terminalWidth <= MaxWidth {
@@ -79,6 +92,16 @@ func CenterView(content string, terminalWidth, terminalHeight int) string {
 	)
 }
 
+// CenterViewMiddle centers content both horizontally and vertically in the
+// terminal. Lists stay top-aligned with CenterView; this is for prompts,
+// confirmations and empty states.
+func CenterViewMiddle(content string, terminalWidth, terminalHeight int) string {
+	return lipgloss.Place(terminalWidth, terminalHeight,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
 // Styles holds all the pre-computed styles for the UI
 type Styles struct {
 	// App container
@@ -193,7 +216,7 @@ func NewStyles() *Styles {
 			Bold(true),
 
 		Tag: lipgloss.NewStyle().
-			Padding(0, 1).
+			Foreground(t.Accent).
 			MarginRight(1),
 
 		TaskItem: lipgloss.NewStyle().
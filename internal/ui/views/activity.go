@@ -0,0 +1,178 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// activityWeeks is how many weeks of history the heatmap shows.
+const activityWeeks = 12
+
+// heatCells are the glyphs for each activity level, from none to most.
+var heatCells = []string{"··", "░░", "▒▒", "▓▓", "██"}
+
+type ActivityView struct {
+	fizzy  *fizzy.Fizzy
+	board  models.Board
+	styles *styles.Styles
+	keys   keys.KeyMap
+
+	width  int
+	height int
+
+	counts  map[string]int
+	loaded  bool
+	loadErr error
+}
+
+func NewActivityView(f *fizzy.Fizzy, board models.Board) *ActivityView {
+	return &ActivityView{
+		fizzy:  f,
+		board:  board,
+		styles: styles.NewStyles(),
+		keys:   keys.DefaultKeyMap(),
+	}
+}
+
+type ShowActivity struct {
+	Board models.Board
+}
+
+type BackToCards struct{}
+
+type activityLoadedMsg struct {
+	counts map[string]int
+	err    error
+}
+
+func (v *ActivityView) Init() tea.Cmd {
+	return v.loadActivity
+}
+
+func (v *ActivityView) loadActivity() tea.Msg {
+	counts, err := v.fizzy.ActivityByDay(v.board.ID, activityWeeks*7)
+	return activityLoadedMsg{counts: counts, err: err}
+}
+
+func (v *ActivityView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case activityLoadedMsg:
+		v.counts = msg.counts
+		v.loadErr = msg.err
+		v.loaded = true
+		return v, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, v.keys.Quit):
+			return v, tea.Quit
+		case key.Matches(msg, v.keys.Back), msg.String() == "A":
+			return v, func() tea.Msg { return BackToCards{} }
+		}
+	}
+	return v, nil
+}
+
+func (v *ActivityView) View() string {
+	s := v.styles
+
+	var body string
+	switch {
+	case !v.loaded:
+		body = s.TitleMuted.Render("Loading...")
+	case v.loadErr != nil:
+		body = s.TitleMuted.Foreground(styles.Current.Error).Render("Failed to load activity")
+	default:
+		body = v.renderHeatmap()
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Activity: "+v.board.Name),
+		s.TitleMuted.Render(fmt.Sprintf("Cards created over the last %d weeks", activityWeeks)),
+		"",
+		body,
+		"",
+		s.Help.Render(fmt.Sprintf("%s back • %s quit",
+			s.HelpKey.Render("esc"),
+			s.HelpKey.Render("q"),
+		)),
+	)
+
+	return styles.CenterViewMiddle(content, v.width, v.height)
+}
+
+func (v *ActivityView) renderHeatmap() string {
+	s := v.styles
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Columns are weeks starting on Sunday, ending with the current week.
+	start := today.AddDate(0, 0, -int(today.Weekday())-(activityWeeks-1)*7)
+
+	peak, total := 0, 0
+	for _, n := range v.counts {
+		peak = max(peak, n)
+		total += n
+	}
+
+	dayLabels := []string{"   ", "Mon", "   ", "Wed", "   ", "Fri", "   "}
+	rows := make([]string, 7)
+	for weekday := range 7 {
+		var row strings.Builder
+		row.WriteString(s.TitleMuted.Render(dayLabels[weekday]) + " ")
+		for week := range activityWeeks {
+			day := start.AddDate(0, 0, week*7+weekday)
+			if day.After(today) {
+				row.WriteString("  ")
+				continue
+			}
+			row.WriteString(v.renderCell(v.counts[day.Format("2006-01-02")], peak))
+		}
+		rows[weekday] = row.String()
+	}
+
+	legend := s.TitleMuted.Render("Less ")
+	for level := range heatCells {
+		legend += v.renderLevel(level)
+	}
+	legend += s.TitleMuted.Render(" More")
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		legend,
+		s.TitleMuted.Render(fmt.Sprintf("%d cards created", total)),
+	)
+}
+
+// renderCell scales a day's count against the busiest day into one of the heat levels.
+func (v *ActivityView) renderCell(count, peak int) string {
+	level := 0
+	if count > 0 && peak > 0 {
+		steps := len(heatCells) - 1
+		level = clamp((count*steps+peak-1)/peak, 1, steps)
+	}
+	return v.renderLevel(level)
+}
+
+func (v *ActivityView) renderLevel(level int) string {
+	color := styles.Current.Success
+	if level == 0 {
+		color = styles.Current.Border
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(heatCells[level])
+}
@@ -0,0 +1,132 @@
+package views
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+)
+
+// autoArchiveDaysSettingKey archives cards stm closed more than this many days ago.
+const autoArchiveDaysSettingKey = "auto_archive_days"
+
+// isArchived reports whether a closed card has been archived. Reopened cards
+// are never treated as archived, however they were reopened.
+func (v *CardListView) isArchived(card models.Card) bool {
+	return card.ColumnID == "done" && v.settings.IsArchived(card.Number)
+}
+
+// toggleArchived archives a closed card, or restores one that is already archived.
+func (v *CardListView) toggleArchived(card models.Card) {
+	switch {
+	case v.isArchived(card):
+		_ = v.settings.UnarchiveCard(card.Number)
+		v.status = fmt.Sprintf("Restored #%d", card.Number)
+	case card.ColumnID != "done":
+		v.status = "Only closed cards can be archived"
+		return
+	default:
+		_ = v.settings.ArchiveCard(card.Number, time.Now())
+		v.status = fmt.Sprintf("Archived #%d", card.Number)
+	}
+	v.clampVisibleState()
+}
+
+// clearClosed forgets a reopened card's close time and archived state, so
+// closing it again starts afresh.
+func (v *CardListView) clearClosed(number int) {
+	_ = v.settings.SetClosedAt(number, time.Time{})
+	_ = v.settings.UnarchiveCard(number)
+}
+
+func (v *CardListView) toggleArchiveBrowser() tea.Cmd {
+	v.showArchived = !v.showArchived
+	v.cursor = 0
+	v.scrollY = 0
+	return v.loadCards
+}
+
+// autoArchiveCutoff returns the auto_archive_days setting and the close time
+// before which cards are archived, and false when the setting is unset.
+func autoArchiveCutoff(settings *fizzy.Settings) (int, time.Time, bool) {
+	days, err := strconv.Atoi(strings.TrimSpace(settings.Get(autoArchiveDaysSettingKey)))
+	if err != nil || days <= 0 {
+		return 0, time.Time{}, false
+	}
+	return days, time.Now().AddDate(0, 0, -days), true
+}
+
+// AutoArchiveChecked carries the cards due for archiving that fizzy still
+// lists as closed.
+type AutoArchiveChecked struct {
+	closed []int
+	days   int
+}
+
+// AutoArchive starts the startup pass that archives cards stm closed more
+// than auto_archive_days ago, on every board. It returns nil when the setting
+// is unset or no card is due, so most startups cost no fizzy calls. A card
+// reopened outside stm keeps its old close time, so only cards fizzy still
+// lists as closed are archived.
+func AutoArchive(f *fizzy.Fizzy, settings *fizzy.Settings) tea.Cmd {
+	days, cutoff, ok := autoArchiveCutoff(settings)
+	if !ok {
+		return nil
+	}
+	due := settings.ClosedBefore(cutoff)
+	if len(due) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		boards, err := f.ListBoards()
+		if err != nil {
+			slog.Warn("auto-archive", "err", err)
+			return nil
+		}
+		closed := make(map[int]bool)
+		for _, b := range boards {
+			cards, err := f.ListCardsByColumn(b.ID, "", true)
+			if err != nil {
+				slog.Warn("auto-archive", "board", b.Name, "err", err)
+				return nil
+			}
+			for _, c := range cards {
+				if c.ColumnID == "done" {
+					closed[c.Number] = true
+				}
+			}
+		}
+
+		msg := AutoArchiveChecked{days: days}
+		for _, n := range due {
+			if closed[n] {
+				msg.closed = append(msg.closed, n)
+			}
+		}
+		return msg
+	}
+}
+
+// Apply archives the checked cards and returns a line for the status bar, or
+// "" when there were none. It writes settings, so it runs in Update rather than
+// in the command.
+func (m AutoArchiveChecked) Apply(settings *fizzy.Settings) string {
+	for _, n := range m.closed {
+		if err := settings.ArchiveCard(n, time.Now()); err != nil {
+			slog.Warn("auto-archive", "card", n, "err", err)
+			return ""
+		}
+	}
+	switch len(m.closed) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("Archived 1 card closed over %d days ago", m.days)
+	}
+	return fmt.Sprintf("Archived %d cards closed over %d days ago", len(m.closed), m.days)
+}
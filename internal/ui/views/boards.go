@@ -25,8 +25,11 @@ func (i boardItem) Description() string { return "" }
 func (i boardItem) FilterValue() string { return i.board.Name }
 
 type boardDelegate struct {
-	styles *styles.Styles
-	width  int
+	styles   *styles.Styles
+	settings *fizzy.Settings
+	width    int
+	progress map[string]boardProgress
+	maxOpen  int // most open cards on any board, which fills the workload dots
 }
 
 func (d boardDelegate) Height() int                               { return 2 }
@@ -51,14 +54,25 @@ func (d boardDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 		descStyle = d.styles.ListItem.Foreground(styles.Current.ForegroundDim).Width(width)
 	}
 
-	title := titleStyle.Render(b.Title())
+	name := b.Title()
+	if badge := boardBadge(d.settings, b.board); badge != "" {
+		name = badge + " " + name
+	}
+	if load := renderWorkload(d.styles, d.progress[b.board.ID], d.maxOpen); load != "" {
+		name += "  " + load
+	}
+	title := titleStyle.Render(name)
 	desc := descStyle.Render(b.Description())
+	if bar := renderProgressBar(d.styles, d.progress[b.board.ID]); bar != "" {
+		desc = descStyle.Render(bar)
+	}
 
 	fmt.Fprintf(w, "%s\n%s", title, desc)
 }
 
 type BoardListView struct {
 	fizzy            *fizzy.Fizzy
+	settings         *fizzy.Settings
 	list             list.Model
 	delegate         *boardDelegate
 	styles           *styles.Styles
@@ -66,6 +80,8 @@ type BoardListView struct {
 	width            int
 	height           int
 	creating         bool
+	cloneSource      *models.Board // set when the create form is cloning a board
+	createError      string
 	loaded           bool
 	confirmingDelete bool
 	deleteTargetID   string
@@ -76,17 +92,38 @@ type BoardListView struct {
 	confirmingDiscard bool
 	originalName      string
 
+	stylingBoard    bool
+	styleBoardID    string
+	styleBoardName  string
+	styleColor      textinput.Model
+	styleIcon       textinput.Model
+	styleFocusIdx   int // 0=color, 1=icon
+	styleColorError bool
+
 	showHelpPopup bool
+	status        string
+
+	// selectedBoardID is the board the user last chose to highlight; filtering
+	// keeps it selected while it still matches.
+	selectedBoardID string
 }
 
-func NewBoardListView(f *fizzy.Fizzy) *BoardListView {
+func NewBoardListView(f *fizzy.Fizzy, settings *fizzy.Settings) *BoardListView {
 	s := styles.NewStyles()
 
 	newName := textinput.New()
 	newName.Placeholder = "Board name"
 	newName.CharLimit = 100
 
-	delegate := &boardDelegate{styles: s, width: 80}
+	styleColor := textinput.New()
+	styleColor.Placeholder = "#7aa2f7"
+	styleColor.CharLimit = 7
+
+	styleIcon := textinput.New()
+	styleIcon.Placeholder = "★"
+	styleIcon.CharLimit = 1
+
+	delegate := &boardDelegate{styles: s, settings: settings, width: 80}
 
 	l := list.New([]list.Item{}, delegate, 0, 0)
 	l.Title = "Boards"
@@ -94,14 +131,18 @@ func NewBoardListView(f *fizzy.Fizzy) *BoardListView {
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = s.Title
 	l.SetShowHelp(false)
+	l.InfiniteScrolling = settings.Bool(wrapNavigationSettingKey)
 
 	return &BoardListView{
-		fizzy:    f,
-		list:     l,
-		delegate: delegate,
-		styles:   s,
-		keys:     keys.DefaultKeyMap(),
-		newName:  newName,
+		fizzy:      f,
+		settings:   settings,
+		list:       l,
+		delegate:   delegate,
+		styles:     s,
+		keys:       keys.DefaultKeyMap(),
+		newName:    newName,
+		styleColor: styleColor,
+		styleIcon:  styleIcon,
 	}
 }
 
@@ -117,19 +158,47 @@ func (v *BoardListView) loadBoards() tea.Msg {
 	return boardsLoadedMsg{boards: boards}
 }
 
-func (v *BoardListView) SetBoards(boards []models.Board) {
+// SetBoards fills the list and returns the command that loads each board's
+// progress for its completion bar and workload dots.
+func (v *BoardListView) SetBoards(boards []models.Board) tea.Cmd {
 	items := make([]list.Item, len(boards))
 	for i, b := range boards {
 		items[i] = boardItem{board: b}
 	}
 	v.list.SetItems(items)
 	v.loaded = true
+	v.restoreSelection()
+	return v.loadProgress(boards)
+}
+
+// SetStatus shows a message on the status line until the next key press.
+func (v *BoardListView) SetStatus(status string) {
+	v.status = status
 }
 
 type boardsLoadedMsg struct {
 	boards []models.Board
 }
 
+type boardProgressLoadedMsg struct {
+	progress map[string]boardProgress
+}
+
+// loadProgress counts closed cards on each board for the completion bars.
+func (v *BoardListView) loadProgress(boards []models.Board) tea.Cmd {
+	return func() tea.Msg {
+		progress := make(map[string]boardProgress, len(boards))
+		for _, b := range boards {
+			closed, total, err := v.fizzy.CountCompleted(b.ID)
+			if err != nil {
+				continue
+			}
+			progress[b.ID] = boardProgress{closed: closed, total: total}
+		}
+		return boardProgressLoadedMsg{progress: progress}
+	}
+}
+
 type SelectedBoard struct {
 	Board models.Board
 }
@@ -145,10 +214,23 @@ func (v *BoardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case boardsLoadedMsg:
-		v.SetBoards(msg.boards)
+		return v, v.SetBoards(msg.boards)
+
+	case CaptureDone:
+		// The capture may have created the inbox board
+		return v, v.loadBoards
+
+	case boardProgressLoadedMsg:
+		v.delegate.progress = msg.progress
+		v.delegate.maxOpen = 0
+		for _, p := range msg.progress {
+			v.delegate.maxOpen = max(v.delegate.maxOpen, p.open())
+		}
 		return v, nil
 
 	case tea.KeyMsg:
+		v.status = ""
+
 		if v.showHelpPopup {
 			v.showHelpPopup = false
 			return v, nil
@@ -166,6 +248,15 @@ func (v *BoardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v.updateCreating(msg)
 		}
 
+		if v.stylingBoard {
+			return v.updateStyling(msg)
+		}
+
+		// While typing a filter, every key belongs to the filter input.
+		if v.list.SettingFilter() {
+			return v, v.updateList(msg)
+		}
+
 		switch {
 		case key.Matches(msg, v.keys.Quit):
 			return v, tea.Quit
@@ -173,20 +264,44 @@ func (v *BoardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, nil
 		case key.Matches(msg, v.keys.New):
 			v.creating = true
+			v.cloneSource = nil
+			v.createError = ""
 			v.focusIdx = 0
 			v.newName.Reset()
 			v.newName.Focus()
 			v.originalName = ""
 			return v, textinput.Blink
+		case msg.String() == "D":
+			if item, ok := v.list.SelectedItem().(boardItem); ok {
+				board := item.board
+				v.creating = true
+				v.cloneSource = &board
+				v.createError = ""
+				v.focusIdx = 0
+				v.newName.SetValue(board.Name + " copy")
+				v.newName.Focus()
+				v.originalName = v.newName.Value()
+				return v, textinput.Blink
+			}
 		case msg.String() == "?":
 			v.showHelpPopup = true
 			return v, nil
+		case msg.String() == "ctrl+r":
+			v.status = "Refreshed"
+			return v, v.loadBoards
+		case msg.String() == ":":
+			return v, func() tea.Msg { return StartJump{} }
 		case key.Matches(msg, v.keys.Enter):
 			if item, ok := v.list.SelectedItem().(boardItem); ok {
 				return v, func() tea.Msg {
 					return SelectedBoard{Board: item.board}
 				}
 			}
+		case msg.String() == "c":
+			if item, ok := v.list.SelectedItem().(boardItem); ok {
+				v.startStyling(item.board)
+				return v, textinput.Blink
+			}
 		case key.Matches(msg, v.keys.Delete):
 			if item, ok := v.list.SelectedItem().(boardItem); ok {
 				v.confirmingDelete = true
@@ -197,14 +312,65 @@ func (v *BoardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	return v, v.updateList(msg)
+}
+
+// Commands lists the board list's actions for the command palette, or nothing
+// while a form, confirmation or filter is taking input.
+func (v *BoardListView) Commands() []Command {
+	if v.showHelpPopup || v.confirmingDelete || v.confirmingDiscard || v.creating ||
+		v.stylingBoard || v.list.SettingFilter() {
+		return nil
+	}
+	return []Command{
+		{Title: "Open board", Key: tea.KeyMsg{Type: tea.KeyEnter}},
+		runeCommand("New board", "n"),
+		runeCommand("Clone board", "D"),
+		runeCommand("Delete board", "d"),
+		runeCommand("Set board color and icon", "c"),
+		runeCommand("Filter boards", "/"),
+		runeCommand("Jump to card", ":"),
+		runeCommand("Keyboard shortcuts", "?"),
+		{Title: "Refresh", Key: tea.KeyMsg{Type: tea.KeyCtrlR}},
+	}
+}
+
+// updateList forwards a message to the list, keeping the remembered board selected
+// across filter changes when it still matches and falling back to the top match.
+func (v *BoardListView) updateList(msg tea.Msg) tea.Cmd {
+	prevState := v.list.FilterState()
+
 	var cmd tea.Cmd
 	v.list, cmd = v.list.Update(msg)
-	return v, cmd
+
+	_, matchesChanged := msg.(list.FilterMatchesMsg)
+	if matchesChanged || v.list.FilterState() != prevState {
+		v.restoreSelection()
+	} else if _, ok := msg.(tea.KeyMsg); ok && !v.list.SettingFilter() {
+		if item, ok := v.list.SelectedItem().(boardItem); ok {
+			v.selectedBoardID = item.board.ID
+		}
+	}
+	return cmd
+}
+
+func (v *BoardListView) restoreSelection() {
+	visible := v.list.VisibleItems()
+	if len(visible) == 0 {
+		return
+	}
+	for i, item := range visible {
+		if b, ok := item.(boardItem); ok && b.board.ID == v.selectedBoardID {
+			v.list.Select(i)
+			return
+		}
+	}
+	v.list.Select(0)
 }
 
 func (v *BoardListView) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "y", "Y":
+	case "y", "Y", "enter":
 		if err := v.fizzy.DeleteBoard(v.deleteTargetID); err == nil {
 			v.confirmingDelete = false
 			return v, v.loadBoards
@@ -226,15 +392,8 @@ func (v *BoardListView) updateConfirmDiscard(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		return v, nil
 	case "s", "S":
 		v.confirmingDiscard = false
-		name := strings.TrimSpace(v.newName.Value())
-		if name != "" {
-			board, err := v.fizzy.CreateBoard(name)
-			if err == nil {
-				v.creating = false
-				return v, func() tea.Msg {
-					return SelectedBoard{Board: *board}
-				}
-			}
+		if name := strings.TrimSpace(v.newName.Value()); name != "" {
+			return v, v.saveNewBoard(name)
 		}
 		return v, nil
 	case "n", "N", "esc":
@@ -255,37 +414,106 @@ func (v *BoardListView) updateCreating(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case msg.String() == "ctrl+s":
-		name := strings.TrimSpace(v.newName.Value())
-		if name != "" {
-			board, err := v.fizzy.CreateBoard(name)
-			if err == nil {
-				v.creating = false
-				return v, func() tea.Msg {
-					return SelectedBoard{Board: *board}
-				}
-			}
+		if name := strings.TrimSpace(v.newName.Value()); name != "" {
+			return v, v.saveNewBoard(name)
 		}
 		return v, nil
 
 	case key.Matches(msg, v.keys.Enter):
-		name := strings.TrimSpace(v.newName.Value())
-		if name != "" {
-			board, err := v.fizzy.CreateBoard(name)
-			if err == nil {
-				v.creating = false
-				return v, func() tea.Msg {
-					return SelectedBoard{Board: *board}
-				}
-			}
+		if name := strings.TrimSpace(v.newName.Value()); name != "" {
+			return v, v.saveNewBoard(name)
 		}
 		return v, nil
 	}
 
 	var cmd tea.Cmd
+	v.createError = ""
 	v.newName, cmd = v.newName.Update(msg)
 	return v, cmd
 }
 
+// saveNewBoard creates the board from the form and opens it. If nothing was
+// created the form stays open with the error. A clone that fails partway
+// leaves a partial board behind, so the form closes and the list selects that
+// board rather than letting a retry make another one.
+func (v *BoardListView) saveNewBoard(name string) tea.Cmd {
+	board, err := v.createBoard(name)
+	switch {
+	case err == nil:
+		v.creating = false
+		return func() tea.Msg {
+			return SelectedBoard{Board: *board}
+		}
+	case board != nil:
+		v.creating = false
+		v.selectedBoardID = board.ID
+		v.status = fmt.Sprintf("Clone stopped partway: %v • %q has only part of the cards", err, board.Name)
+		return v.loadBoards
+	default:
+		v.createError = err.Error()
+		return nil
+	}
+}
+
+// createBoard creates an empty board, or a copy of cloneSource when cloning.
+func (v *BoardListView) createBoard(name string) (*models.Board, error) {
+	if v.cloneSource != nil {
+		return v.fizzy.CloneBoard(v.cloneSource.ID, name)
+	}
+	return v.fizzy.CreateBoard(name)
+}
+
+func (v *BoardListView) startStyling(board models.Board) {
+	v.stylingBoard = true
+	v.styleBoardID = board.ID
+	v.styleBoardName = board.Name
+	v.styleFocusIdx = 0
+	v.styleColorError = false
+	v.styleColor.SetValue(v.settings.Get(boardColorSettingKey(board.ID)))
+	v.styleIcon.SetValue(v.settings.Get(boardIconSettingKey(board.ID)))
+	v.styleIcon.Blur()
+	v.styleColor.Focus()
+}
+
+func (v *BoardListView) updateStyling(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.stylingBoard = false
+		return v, nil
+
+	case key.Matches(msg, v.keys.Tab), msg.String() == "shift+tab":
+		v.styleFocusIdx = 1 - v.styleFocusIdx
+		if v.styleFocusIdx == 0 {
+			v.styleIcon.Blur()
+			v.styleColor.Focus()
+		} else {
+			v.styleColor.Blur()
+			v.styleIcon.Focus()
+		}
+		return v, nil
+
+	case key.Matches(msg, v.keys.Enter), msg.String() == "ctrl+s":
+		color := strings.TrimSpace(v.styleColor.Value())
+		if color != "" && !isHexColor(color) {
+			v.styleColorError = true
+			return v, nil
+		}
+		_ = v.settings.Set(boardColorSettingKey(v.styleBoardID), color)
+		_ = v.settings.Set(boardIconSettingKey(v.styleBoardID), strings.TrimSpace(v.styleIcon.Value()))
+		v.stylingBoard = false
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.styleFocusIdx == 0 {
+		v.styleColorError = false
+		v.styleColor, cmd = v.styleColor.Update(msg)
+	} else {
+		v.styleIcon, cmd = v.styleIcon.Update(msg)
+	}
+	return v, cmd
+}
+
 func (v *BoardListView) hasUnsavedChanges() bool {
 	return v.newName.Value() != v.originalName
 }
@@ -307,8 +535,12 @@ func (v *BoardListView) View() string {
 		return v.renderCreateForm()
 	}
 
+	if v.stylingBoard {
+		return v.renderStyleForm()
+	}
+
 	if !v.loaded {
-		return v.styles.TitleMuted.Render("Loading...")
+		return styles.CenterViewMiddle(v.styles.TitleMuted.Render("Loading..."), v.width, v.height)
 	}
 
 	if len(v.list.Items()) == 0 {
@@ -321,7 +553,6 @@ func (v *BoardListView) View() string {
 
 func (v *BoardListView) renderEmpty() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Render("No Boards"),
@@ -331,11 +562,7 @@ func (v *BoardListView) renderEmpty() string {
 		s.ButtonPrimary.Render(" New Board "),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(content, v.width, v.height)
 }
 
 func (v *BoardListView) renderCreateForm() string {
@@ -354,8 +581,13 @@ func (v *BoardListView) renderCreateForm() string {
 
 	inputWidth := clamp(contentWidth-6, 20, 50)
 
+	formTitle := "New Board"
+	if v.cloneSource != nil {
+		formTitle = "Clone " + v.cloneSource.Name
+	}
+
 	form := lipgloss.JoinVertical(lipgloss.Left,
-		s.Title.Render("New Board"),
+		s.Title.Render(formTitle),
 		"",
 		"Name:",
 		nameStyle.Width(inputWidth).Render(v.newName.View()),
@@ -364,17 +596,46 @@ func (v *BoardListView) renderCreateForm() string {
 		"",
 		s.TitleMuted.Render("↵: create • Esc: cancel"),
 	)
+	if v.createError != "" {
+		form = lipgloss.JoinVertical(lipgloss.Left, form, "",
+			lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.createError))
+	}
+
+	return styles.CenterViewMiddle(form, v.width, v.height)
+}
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		form,
+func (v *BoardListView) renderStyleForm() string {
+	s := v.styles
+	contentWidth := styles.ContentWidth(v.width)
+	inputWidth := clamp(contentWidth-6, 20, 50)
+
+	colorStyle, iconStyle := s.InputFocused, s.Input
+	if v.styleFocusIdx == 1 {
+		colorStyle, iconStyle = s.Input, s.InputFocused
+	}
+
+	colorLabel := "Color (hex):"
+	if v.styleColorError {
+		colorLabel += " " + lipgloss.NewStyle().Foreground(styles.Current.Error).Render("invalid color")
+	}
+
+	form := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Board Appearance: "+v.styleBoardName),
+		"",
+		colorLabel,
+		colorStyle.Width(inputWidth).Render(v.styleColor.View()),
+		"",
+		"Icon:",
+		iconStyle.Width(inputWidth).Render(v.styleIcon.View()),
+		"",
+		s.TitleMuted.Render("Tab: next • ↵: save • Esc: cancel • leave empty for default"),
 	)
-	return styles.CenterView(centered, v.width, v.height)
+
+	return styles.CenterViewMiddle(form, v.width, v.height)
 }
 
 func (v *BoardListView) renderDiscardConfirm() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Foreground(styles.Current.Warning).Render("Discard unsaved changes?"),
@@ -389,22 +650,22 @@ func (v *BoardListView) renderDiscardConfirm() string {
 		),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(content, v.width, v.height)
 }
 
 func (v *BoardListView) renderHelp() string {
+	if v.status != "" {
+		return v.styles.Help.Render(v.status)
+	}
 	contentWidth := styles.ContentWidth(v.width)
 	if contentWidth > 0 && contentWidth < 50 {
 		return v.styles.Help.Render(v.styles.HelpKey.Render("?") + " help")
 	}
 	return v.styles.Help.Render(
-		fmt.Sprintf("%s select • %s new • %s del • %s quit",
+		fmt.Sprintf("%s select • %s new • %s color • %s del • %s quit",
 			v.styles.HelpKey.Render("↵"),
 			v.styles.HelpKey.Render("n"),
+			v.styles.HelpKey.Render("c"),
 			v.styles.HelpKey.Render("d"),
 			v.styles.HelpKey.Render("q"),
 		),
@@ -413,14 +674,20 @@ func (v *BoardListView) renderHelp() string {
 
 func (v *BoardListView) renderHelpPopup() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	helpItems := []string{
 		s.HelpKey.Render("↵") + "      select board",
 		s.HelpKey.Render("n") + "      new board",
+		s.HelpKey.Render("c") + "      set color/icon",
 		s.HelpKey.Render("d") + "      delete board",
+		s.HelpKey.Render(":") + "      jump to card #",
+		s.HelpKey.Render("D") + "      clone board",
+		s.HelpKey.Render("ctrl+o") + " quick capture to inbox",
+		s.HelpKey.Render("ctrl+p") + " command palette",
+		s.HelpKey.Render("ctrl+r") + " refresh",
 		s.HelpKey.Render("q") + "      quit",
 		"",
+		s.TitleMuted.Render("Settings: " + v.settings.Path()),
 		s.TitleMuted.Render("Press any key to close"),
 	}
 
@@ -428,31 +695,62 @@ func (v *BoardListView) renderHelpPopup() string {
 		append([]string{s.Title.Render("Keyboard Shortcuts"), ""}, helpItems...)...,
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		s.FilterBar.Render(content),
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
 }
 
 func (v *BoardListView) renderDeleteConfirm() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Foreground(styles.Current.Error).Render("Delete Board?"),
 		"",
 		"",
 		lipgloss.JoinHorizontal(lipgloss.Center,
-			s.ButtonPrimary.Render(" Y - Yes "),
+			s.ButtonPrimary.Render(" ↵ Y - Yes "),
 			"  ",
 			s.Button.Render(" N - No "),
 		),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(content, v.width, v.height)
+}
+
+func boardColorSettingKey(boardID string) string {
+	return "board_color:" + boardID
+}
+
+func boardIconSettingKey(boardID string) string {
+	return "board_icon:" + boardID
+}
+
+// boardBadge renders a board's configured icon in its configured color. A color without
+// an icon renders as a dot; with neither configured it returns an empty string.
+func boardBadge(settings *fizzy.Settings, board models.Board) string {
+	if settings == nil {
+		return ""
+	}
+	color := settings.Get(boardColorSettingKey(board.ID))
+	icon := settings.Get(boardIconSettingKey(board.ID))
+	if icon == "" {
+		if color == "" {
+			return ""
+		}
+		icon = "●"
+	}
+	if color == "" {
+		return icon
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(icon)
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,180 @@
+package views
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// inboxBoardSettingKey names the board quick captures go to.
+const inboxBoardSettingKey = "inbox_board"
+
+// defaultInboxBoard is used when inbox_board is unset; it is created on first capture.
+const defaultInboxBoard = "Inbox"
+
+// CaptureView is a single-line prompt that files a new card on the inbox board
+type CaptureView struct {
+	fizzy    *fizzy.Fizzy
+	settings *fizzy.Settings
+	styles   *styles.Styles
+	keys     keys.KeyMap
+	input    textinput.Model
+
+	width  int
+	height int
+	saving bool
+	errMsg string
+}
+
+func NewCaptureView(f *fizzy.Fizzy, settings *fizzy.Settings) *CaptureView {
+	input := textinput.New()
+	input.Placeholder = "What needs doing?"
+	input.CharLimit = 200
+	input.Focus()
+
+	return &CaptureView{
+		fizzy:    f,
+		settings: settings,
+		styles:   styles.NewStyles(),
+		keys:     keys.DefaultKeyMap(),
+		input:    input,
+	}
+}
+
+// CancelCapture closes the capture prompt without creating a card
+type CancelCapture struct{}
+
+// CaptureDone is sent once a captured card has been created
+type CaptureDone struct {
+	Board models.Board
+	Card  models.Card
+}
+
+type captureFailedMsg struct {
+	err error
+}
+
+func (v *CaptureView) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (v *CaptureView) inboxName() string {
+	return inboxBoardName(v.settings)
+}
+
+// inboxBoardName returns the board named by inbox_board, or the default inbox
+func inboxBoardName(settings *fizzy.Settings) string {
+	if name := strings.TrimSpace(settings.Get(inboxBoardSettingKey)); name != "" {
+		return name
+	}
+	return defaultInboxBoard
+}
+
+func (v *CaptureView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case captureFailedMsg:
+		v.saving = false
+		v.errMsg = "Capture failed: " + msg.err.Error()
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.saving {
+			return v, nil
+		}
+		switch {
+		case key.Matches(msg, v.keys.Back):
+			return v, func() tea.Msg { return CancelCapture{} }
+		case key.Matches(msg, v.keys.Enter):
+			title, tags := splitInlineTags(v.input.Value())
+			if title == "" {
+				if len(tags) > 0 {
+					v.errMsg = "Add a title as well as tags"
+					return v, nil
+				}
+				return v, func() tea.Msg { return CancelCapture{} }
+			}
+			v.saving = true
+			v.errMsg = ""
+			return v, v.capture(title, tags)
+		}
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *CaptureView) capture(title string, tags []string) tea.Cmd {
+	inbox := v.inboxName()
+	return func() tea.Msg {
+		board, err := v.fizzy.EnsureBoard(inbox)
+		if err != nil {
+			return captureFailedMsg{err: err}
+		}
+		card, err := v.fizzy.CreateCard(board.ID, title, "")
+		if err != nil {
+			return captureFailedMsg{err: err}
+		}
+		// fizzy creates a tag the first time a card is given it
+		for _, tag := range tags {
+			if _, err := v.fizzy.AddTag(card, tag); err != nil {
+				return captureFailedMsg{err: err}
+			}
+		}
+		return CaptureDone{Board: *board, Card: *card}
+	}
+}
+
+// splitInlineTags pulls #tag words out of a capture line, returning the rest as
+// the title. Bare "#" and card references such as "#12" stay in the title.
+func splitInlineTags(text string) (title string, tags []string) {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		name, ok := strings.CutPrefix(word, "#")
+		if !ok || name == "" || strings.Trim(name, "0123456789") == "" {
+			words = append(words, word)
+			continue
+		}
+		if !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	return strings.Join(words, " "), tags
+}
+
+func (v *CaptureView) View() string {
+	s := v.styles
+	contentWidth := styles.ContentWidth(v.width)
+	inputWidth := clamp(contentWidth-6, 20, 50)
+
+	status := s.TitleMuted.Render("#tag to tag • ↵: capture • Esc: cancel")
+	switch {
+	case v.saving:
+		status = s.TitleMuted.Render("Saving...")
+	case v.errMsg != "":
+		status = lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.errMsg)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Capture to "+v.inboxName()),
+		"",
+		s.InputFocused.Width(inputWidth).Render(v.input.View()),
+		"",
+		status,
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
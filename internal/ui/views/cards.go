@@ -2,20 +2,52 @@ package views
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/dates"
+	"github.com/tgienger/stm/internal/export"
 	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/gitinfo"
 	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/opener"
 	"github.com/tgienger/stm/internal/ui/keys"
 	"github.com/tgienger/stm/internal/ui/styles"
 )
 
+// truncateLine collapses all whitespace, including newlines, to single spaces
+// and cuts the result to width cells, ending with an ellipsis if shortened.
+func truncateLine(text string, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := lipgloss.Width(string(r))
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return strings.TrimRight(b.String(), " ") + "…"
+}
+
 func clamp(val, minVal, maxVal int) int {
 	if val < minVal {
 		return minVal
@@ -26,6 +58,21 @@ func clamp(val, minVal, maxVal int) int {
 	return val
 }
 
+// Edit form fields in tab order
+const (
+	editFieldTitle = iota
+	editFieldDesc
+	editFieldRef
+	editFieldWaiting
+	editFieldFollowUp
+	editFieldRepeat
+	editFieldBlockedBy
+	editFieldEstimate
+	editFieldTags
+	editFieldSave
+	editFieldCount
+)
+
 type FocusArea int
 
 const (
@@ -36,13 +83,15 @@ const (
 )
 
 type CardListView struct {
-	fizzy    *fizzy.Fizzy
-	settings *fizzy.Settings
-	board    models.Board
-	cards    []models.Card
-	tags     []models.Tag
-	styles   *styles.Styles
-	keys     keys.KeyMap
+	fizzy     *fizzy.Fizzy
+	settings  *fizzy.Settings
+	board     models.Board
+	cards     []models.Card
+	tags      []models.Tag
+	tagCounts map[string]int // open cards per tag title on this board
+	progress  boardProgress
+	styles    *styles.Styles
+	keys      keys.KeyMap
 
 	width  int
 	height int
@@ -51,12 +100,27 @@ type CardListView struct {
 	columns                []models.Column
 	currentColumn          int // 0 = All, 1..N = column index+1
 	pendingRestoreColumnID string
+	pendingOpenCard        int // card number to open once cards load
+	pendingSelectCard      int // card number to put the cursor on once cards load
+
+	// Single-slot undo for the last card closed with x
+	lastClosedCard   int
+	lastClosedCursor int
+	lastClosedAt     time.Time
+	lastRepeatCard   int  // next occurrence created when lastClosedCard was closed
+	detailChanged    bool // card was closed or reopened from the detail view
+	progressStale    bool // a card was closed, reopened, created or deleted since the last count
+
+	pinned      map[int]bool // card numbers pinned to the top of the list
+	openNumbers map[int]bool // open cards on the board, for blocked-by checks
+	loadOrder   map[int]int  // card number -> position as returned by fizzy
+	sort        cardSort
 
 	focus       FocusArea
 	cursor      int
 	scrollY     int
 	searchInput textinput.Model
-	selectedTag string // empty = no filter
+	selectedTag string // empty = no filter, untaggedFilter = cards without tags
 
 	tagDropdownOpen bool
 	tagCursor       int
@@ -68,16 +132,63 @@ type CardListView struct {
 	editingNew    bool
 	editTitle     textinput.Model
 	editDesc      textarea.Model
-	editFocusIdx  int // 0=title, 1=desc, 2=tags, 3=save
+	editFocusIdx  int // one of the editField constants
 	editTags      []string
-	editTagCursor int
+	editTagPicker tagPicker
+
+	editRef           textinput.Model
+	editWaiting       textinput.Model
+	editFollowUp      textinput.Model
+	editFollowUpError string
+	editRepeat        textinput.Model
+	editRepeatError   string
+	editBlockedBy     textinput.Model
+	editBlockedError  string
+	editEstimate      textinput.Model
+	editEstimateError string
+	showFollowUps     bool // list only waiting cards whose follow-up date has arrived
+	completedExpanded bool // Completed section in the All column is open
+	closedDisplay     closedDisplay
+
+	groupByTag      bool            // list cards under a header per tag
+	collapsedGroups map[string]bool // tag groups hidden in group-by-tag mode
+
+	searchComments bool             // also match search text against card comments
+	commentIndex   map[int][]string // card number -> lowercased comment bodies
 
 	assigningTags   bool
-	assignTagCursor int
+	confirmUntag    string // tag awaiting confirmation before it is removed
+	assignTagPicker tagPicker
 	assigningCardID int
 
+	// Export menu
+	sharing    bool
+	shareCards []models.Card
+	shareLabel string
+
+	moving           bool
+	moveCard         models.Card
+	moveColumnCursor int
+
+	retagging   bool
+	retagStep   retagStep
+	retagCursor int
+	retagFrom   string
+	retagTo     string
+
+	showSnoozed      bool // list only snoozed cards instead of hiding them
+	showShelved      bool // list only shelved cards instead of hiding them
+	showArchived     bool // list only archived cards, from the whole board
+	snoozing         bool
+	snoozeCardNumber int
+	snoozeInput      textinput.Model
+	snoozeError      string
+
 	viewingCard         bool
 	viewCardComments    []models.Comment
+	showCardHistory     bool // detail view lists every system message, not just the latest
+	hideDetailDesc      bool // detail view folds the description to a summary line
+	hideDetailComments  bool // detail view folds the comments to a summary line
 	commentInput        textarea.Model
 	commentInputFocused bool
 
@@ -89,12 +200,22 @@ type CardListView struct {
 	deleteColumnID         string
 	deleteColumnName       string
 
+	confirmingClearClosed bool
+	clearClosedCount      int
+
 	confirmingDiscard bool
 	originalTitle     string
 	originalDesc      string
 	originalTags      []string
+	originalRef       string
+	originalWaiting   string
+	originalFollowUp  string
+	originalRepeat    string
+	originalBlockedBy string
+	originalEstimate  string
 
 	loadingCards bool
+	status       string // one-line feedback shown above the help bar
 
 	showHelpPopup bool
 }
@@ -124,6 +245,34 @@ func NewCardListView(f *fizzy.Fizzy, settings *fizzy.Settings, board models.Boar
 	commentInput.SetHeight(3)
 	commentInput.ShowLineNumbers = false
 
+	editRef := textinput.New()
+	editRef.Placeholder = "Issue key or URL (GH-123)"
+	editRef.CharLimit = 200
+
+	editWaiting := textinput.New()
+	editWaiting.Placeholder = "Who or what this is waiting on"
+	editWaiting.CharLimit = 100
+
+	editFollowUp := textinput.New()
+	editFollowUp.Placeholder = "Follow up on (+3d, 2025-01-02)"
+	editFollowUp.CharLimit = 20
+
+	editRepeat := textinput.New()
+	editRepeat.Placeholder = "Repeat (daily, weekly, every 2 weeks)"
+	editRepeat.CharLimit = 30
+
+	editBlockedBy := textinput.New()
+	editBlockedBy.Placeholder = "Blocked by cards (#12, #15)"
+	editBlockedBy.CharLimit = 100
+
+	editEstimate := textinput.New()
+	editEstimate.Placeholder = "Estimate (30m, 2h, 1h30m)"
+	editEstimate.CharLimit = 20
+
+	snoozeInput := textinput.New()
+	snoozeInput.Placeholder = "+1d"
+	snoozeInput.CharLimit = 20
+
 	newColumnName := textinput.New()
 	newColumnName.Placeholder = "Column name"
 	newColumnName.CharLimit = 100
@@ -138,21 +287,70 @@ func NewCardListView(f *fizzy.Fizzy, settings *fizzy.Settings, board models.Boar
 		searchInput:            search,
 		editTitle:              editTitle,
 		editDesc:               editDesc,
+		editRef:                editRef,
+		editWaiting:            editWaiting,
+		editFollowUp:           editFollowUp,
+		editRepeat:             editRepeat,
+		editBlockedBy:          editBlockedBy,
+		editEstimate:           editEstimate,
 		newColumnName:          newColumnName,
+		snoozeInput:            snoozeInput,
 		commentInput:           commentInput,
+		editTagPicker:          newTagPicker(),
+		assignTagPicker:        newTagPicker(),
 		loadingCards:           true,
+		progressStale:          true,
 		pendingRestoreColumnID: settings.Get(lastColumnSettingKey(board.ID)),
+		pinned:                 loadPinnedCards(settings, board.ID),
+		selectedTag:            settings.Get(tagFilterSettingKey(board.ID)),
+		closedDisplay:          parseClosedDisplay(settings.Get(closedDisplaySettingKey)),
+		sort: cardSort{
+			field: parseSortField(settings.Get(sortFieldSettingKey(board.ID))),
+			desc:  settings.Get(sortDirSettingKey(board.ID)) == "desc",
+		},
 	}
 }
 
 type BackToBoards struct{}
 
+// OpenCardOnLoad switches to the card's column and opens its detail view once cards load.
+func (v *CardListView) OpenCardOnLoad(card models.Card) {
+	v.pendingOpenCard = card.Number
+	v.pendingRestoreColumnID = card.ColumnID
+}
+
+// SetStatus shows a message on the status line until the next key press.
+func (v *CardListView) SetStatus(status string) {
+	v.status = status
+	v.clampVisibleState()
+}
+
+// refresh reloads the board's columns, cards and tags, staying on the current
+// column if it still exists.
+func (v *CardListView) refresh() tea.Cmd {
+	v.pendingRestoreColumnID = v.currentColumnID()
+	v.status = "Refreshed"
+	v.progressStale = true
+	if v.searchComments {
+		// Rebuilt from scratch once the cards reload
+		v.commentIndex = nil
+	}
+	return tea.Batch(v.loadTags, v.loadTagCounts, v.loadColumns)
+}
+
+// ShowUntagged opens the board on the All column, filtered to untagged cards.
+func (v *CardListView) ShowUntagged() {
+	v.selectedTag = untaggedFilter
+	v.pendingRestoreColumnID = ""
+}
+
 func (v *CardListView) Init() tea.Cmd {
-	return tea.Batch(v.loadTags, v.loadColumns)
+	return tea.Batch(v.loadTags, v.loadTagCounts, v.loadColumns)
 }
 
 type cardsLoadedMsg struct {
 	cards []models.Card
+	all   bool // every card on the board, closed ones included
 }
 
 type cardsLoadErrorMsg struct {
@@ -163,6 +361,19 @@ type tagsLoadedMsg struct {
 	tags []models.Tag
 }
 
+type tagCountsLoadedMsg struct {
+	counts map[string]int
+}
+
+type commentIndexLoadedMsg struct {
+	index map[int][]string
+}
+
+type progressLoadedMsg struct {
+	progress boardProgress
+	open     map[int]bool
+}
+
 type columnsLoadedMsg struct {
 	columns []models.Column
 }
@@ -171,17 +382,24 @@ func (v *CardListView) loadCards() tea.Msg {
 	v.loadingCards = true
 	var cards []models.Card
 	var err error
+	all := false
 
-	if v.currentColumn > 0 && v.currentColumn <= len(v.columns) {
+	if v.showArchived {
+		cards, err = v.fizzy.ListCardsByColumn(v.board.ID, "", true)
+		all = true
+	} else if v.currentColumn > 0 && v.currentColumn <= len(v.columns) {
 		col := v.columns[v.currentColumn-1]
 		cards, err = v.fizzy.ListCardsByColumn(v.board.ID, col.ID, col.Pseudo)
+	} else if v.completedSectionActive() || v.closedDisplayActive() != closedHidden {
+		cards, err = v.fizzy.ListCardsByColumn(v.board.ID, "", true)
+		all = true
 	} else {
 		cards, err = v.fizzy.ListCards(v.board.ID)
 	}
 	if err != nil {
 		return cardsLoadErrorMsg{err: err}
 	}
-	return cardsLoadedMsg{cards: cards}
+	return cardsLoadedMsg{cards: cards, all: all}
 }
 
 func (v *CardListView) loadTags() tea.Msg {
@@ -192,6 +410,79 @@ func (v *CardListView) loadTags() tea.Msg {
 	return tagsLoadedMsg{tags: tags}
 }
 
+func (v *CardListView) loadTagCounts() tea.Msg {
+	counts, err := v.fizzy.CountCardsByTag(v.board.ID)
+	if err != nil {
+		return err
+	}
+	return tagCountsLoadedMsg{counts: counts}
+}
+
+// loadCommentIndex fetches comments for the loaded cards that aren't in the
+// comment index yet, so search can match them. It costs one fizzy call per
+// card, which is why comment search is opt-in and the index is only rebuilt
+// when M turns it on or the board is refreshed.
+func (v *CardListView) loadCommentIndex() tea.Cmd {
+	if v.commentIndex == nil {
+		v.commentIndex = make(map[int][]string)
+	}
+	var numbers []int
+	for _, c := range v.cards {
+		if _, ok := v.commentIndex[c.Number]; !ok {
+			// Claim the card now so reloads while the fetch runs don't repeat it
+			v.commentIndex[c.Number] = nil
+			numbers = append(numbers, c.Number)
+		}
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		index := make(map[int][]string, len(numbers))
+		for _, n := range numbers {
+			comments, err := v.fizzy.ListComments(n)
+			if err != nil {
+				continue
+			}
+			for _, c := range comments {
+				if !isSystemComment(c) {
+					index[n] = append(index[n], strings.ToLower(c.Body))
+				}
+			}
+		}
+		return commentIndexLoadedMsg{index: index}
+	}
+}
+
+// loadProgress counts the board's closed cards for the completion bar and the
+// this-week badge, and notes which cards are open, which decides whether a
+// card is blocked.
+func (v *CardListView) loadProgress() tea.Msg {
+	cards, err := v.fizzy.ListCardsByColumn(v.board.ID, "", true)
+	if err != nil {
+		return err
+	}
+	progress, open := countProgress(v.settings, cards)
+	return progressLoadedMsg{progress: progress, open: open}
+}
+
+// updateProgress keeps the completion bar current after a card load. A load
+// that already lists every card, closed ones included, is counted directly.
+// Otherwise the board is only listed again when progressStale says a card
+// changed since the last count.
+func (v *CardListView) updateProgress(msg cardsLoadedMsg) tea.Cmd {
+	if msg.all {
+		v.progress, v.openNumbers = countProgress(v.settings, msg.cards)
+		v.progressStale = false
+		return nil
+	}
+	if !v.progressStale {
+		return nil
+	}
+	v.progressStale = false
+	return v.loadProgress
+}
+
 func (v *CardListView) loadColumns() tea.Msg {
 	columns, err := v.fizzy.ListColumns(v.board.ID)
 	if err != nil {
@@ -200,15 +491,62 @@ func (v *CardListView) loadColumns() tea.Msg {
 	return columnsLoadedMsg{columns: columns}
 }
 
+// filteredCards returns the cards the list shows, in display order.
 func (v *CardListView) filteredCards() []models.Card {
-	search := strings.ToLower(strings.TrimSpace(v.searchInput.Value()))
+	cards := v.ungroupedCards()
+	if v.groupByTag {
+		return v.flattenGroups(groupByTag(cards))
+	}
+	return cards
+}
+
+// ungroupedCards returns the visible cards before any group-by-tag regrouping.
+func (v *CardListView) ungroupedCards() []models.Card {
+	cards := v.matchingCards()
+	switch v.closedDisplayActive() {
+	case closedOnly:
+		_, closed := partitionClosed(cards)
+		return closed
+	case closedDimmed:
+		open, closed := partitionClosed(cards)
+		return append(open, closed...)
+	}
+	if !v.completedSectionActive() {
+		return cards
+	}
+	open, closed := partitionClosed(cards)
+	if v.completedExpanded {
+		return append(open, closed...)
+	}
+	return open
+}
+
+// matchingCards applies the search, tag and view filters to the loaded cards.
+func (v *CardListView) matchingCards() []models.Card {
+	query := parseSearchQuery(v.searchInput.Value())
+	hideBlocked := v.settings.Bool(hideBlockedSettingKey)
 	var result []models.Card
 	for _, c := range v.cards {
-		if search != "" && !strings.Contains(strings.ToLower(c.Title), search) &&
-			!strings.Contains(strings.ToLower(c.Description), search) {
+		if v.isSnoozed(c) != v.showSnoozed || v.isShelved(c) != v.showShelved || v.isArchived(c) != v.showArchived {
+			continue
+		}
+		if v.showFollowUps && !v.followUpDue(c) {
+			continue
+		}
+		if hideBlocked && v.isBlocked(c) {
+			continue
+		}
+		if !query.matchesFilters(c) {
+			continue
+		}
+		if !query.matchesText(c) && !v.matchesExtra(query, c) {
 			continue
 		}
-		if v.selectedTag != "" {
+		if v.selectedTag == untaggedFilter {
+			if len(c.Tags) > 0 {
+				continue
+			}
+		} else if v.selectedTag != "" {
 			found := false
 			for _, t := range c.Tags {
 				if t == v.selectedTag {
@@ -222,9 +560,43 @@ func (v *CardListView) filteredCards() []models.Card {
 		}
 		result = append(result, c)
 	}
+	if query.text != "" {
+		// Title matches rank above description and comment matches, each group
+		// keeping the usual order
+		var inTitle, elsewhere []models.Card
+		for _, c := range result {
+			if query.matchesTitle(c) {
+				inTitle = append(inTitle, c)
+			} else {
+				elsewhere = append(elsewhere, c)
+			}
+		}
+		result = append(inTitle, elsewhere...)
+	}
 	return result
 }
 
+// selectedCard returns the card under the cursor in the visible (filtered) list.
+func (v *CardListView) selectedCard() (models.Card, bool) {
+	filtered := v.filteredCards()
+	if v.cursor < 0 || v.cursor >= len(filtered) {
+		return models.Card{}, false
+	}
+	return filtered[v.cursor], true
+}
+
+// selectCard moves the cursor onto the visible card with the given number.
+func (v *CardListView) selectCard(number int) bool {
+	for i, c := range v.filteredCards() {
+		if c.Number == number {
+			v.cursor = i
+			v.ensureVisible()
+			return true
+		}
+	}
+	return false
+}
+
 func (v *CardListView) clampVisibleState() {
 	filtered := v.filteredCards()
 	if len(filtered) == 0 {
@@ -259,9 +631,41 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case cardsLoadedMsg:
+		// While searching, edits and other reloads keep the results where they were
+		var shown map[int]int
+		if strings.TrimSpace(v.searchInput.Value()) != "" && len(v.cards) > 0 {
+			shown = make(map[int]int, len(v.cards))
+			for i, c := range v.cards {
+				shown[c.Number] = i
+			}
+		}
+
 		v.cards = msg.cards
+		v.loadOrder = make(map[int]int, len(v.cards))
+		for i, c := range v.cards {
+			v.loadOrder[c.Number] = i
+		}
+		v.sortCards()
+		if shown != nil {
+			keepOrder(v.cards, shown)
+		}
 		v.loadingCards = false
 		v.clampVisibleState()
+		if v.pendingSelectCard != 0 {
+			if !v.selectCard(v.pendingSelectCard) {
+				v.cursor = v.lastClosedCursor
+				v.clampVisibleState()
+			}
+			v.pendingSelectCard = 0
+		}
+		if v.pendingOpenCard != 0 {
+			number := v.pendingOpenCard
+			v.pendingOpenCard = 0
+			if v.selectCard(number) {
+				v.viewingCard = true
+				return v, tea.Batch(v.loadCardComments, v.updateProgress(msg))
+			}
+		}
 		if v.assigningTags && v.assigningCardID != 0 {
 			found := false
 			for _, c := range v.cards {
@@ -275,7 +679,11 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				v.assigningCardID = 0
 			}
 		}
-		return v, nil
+		progress := v.updateProgress(msg)
+		if v.searchComments {
+			return v, tea.Batch(progress, v.loadCommentIndex())
+		}
+		return v, progress
 
 	case cardsLoadErrorMsg:
 		v.loadingCards = false
@@ -284,6 +692,44 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tagsLoadedMsg:
 		v.tags = msg.tags
+		// Drop a remembered filter whose tag no longer exists
+		if v.selectedTag != "" && v.selectedTag != untaggedFilter && !slices.ContainsFunc(v.tags, func(t models.Tag) bool { return t.Title == v.selectedTag }) {
+			v.selectedTag = ""
+			_ = v.settings.Set(tagFilterSettingKey(v.board.ID), "")
+			v.clampVisibleState()
+		}
+		return v, nil
+
+	case tagCountsLoadedMsg:
+		v.tagCounts = msg.counts
+		return v, nil
+
+	case progressLoadedMsg:
+		v.progress = msg.progress
+		v.openNumbers = msg.open
+		v.clampVisibleState()
+		return v, nil
+
+	case commentIndexLoadedMsg:
+		if !v.searchComments {
+			return v, nil
+		}
+		for n, bodies := range msg.index {
+			v.commentIndex[n] = bodies
+		}
+		v.clampVisibleState()
+		return v, nil
+
+	case CaptureDone:
+		v.status = fmt.Sprintf("Captured #%d to %s", msg.Card.Number, msg.Board.Name)
+		if msg.Board.ID == v.board.ID {
+			v.progressStale = true
+			return v, v.loadCards
+		}
+		return v, nil
+
+	case previousBoardMissingMsg:
+		v.status = "No previous board to switch to"
 		return v, nil
 
 	case columnsLoadedMsg:
@@ -296,6 +742,8 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case tea.KeyMsg:
+		v.status = ""
+
 		if v.showHelpPopup {
 			v.showHelpPopup = false
 			return v, nil
@@ -309,6 +757,10 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v.updateConfirmDeleteColumn(msg)
 		}
 
+		if v.confirmingClearClosed {
+			return v.updateConfirmClearClosed(msg)
+		}
+
 		if v.confirmingDiscard {
 			return v.updateConfirmDiscard(msg)
 		}
@@ -321,6 +773,14 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v.updateEditing(msg)
 		}
 
+		if v.snoozing {
+			return v.updateSnoozing(msg)
+		}
+
+		if v.sharing {
+			return v.updateSharing(msg)
+		}
+
 		if v.viewingCard {
 			return v.updateViewingCard(msg)
 		}
@@ -329,6 +789,14 @@ func (v *CardListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v.updateAssigningTags(msg)
 		}
 
+		if v.retagging {
+			return v.updateRetagging(msg)
+		}
+
+		if v.moving {
+			return v.updateMoving(msg)
+		}
+
 		if v.tagDropdownOpen {
 			return v.updateTagDropdown(msg)
 		}
@@ -374,16 +842,35 @@ func (v *CardListView) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case key.Matches(msg, v.keys.Up):
-		if v.focus == FocusCardList && v.cursor > 0 {
-			v.cursor--
-			v.ensureVisible()
+		if v.focus == FocusCardList {
+			v.moveCursor(-1)
 		}
 		return v, nil
 
 	case key.Matches(msg, v.keys.Down):
-		if v.focus == FocusCardList && v.cursor < len(v.cards)-1 {
-			v.cursor++
-			v.ensureVisible()
+		if v.focus == FocusCardList {
+			v.moveCursor(1)
+		}
+		return v, nil
+
+	case msg.String() == "ctrl+r":
+		return v, v.refresh()
+
+	case msg.String() == "pgup", msg.String() == "ctrl+u":
+		if v.focus == FocusCardList {
+			v.pageCursor(-1)
+		}
+		return v, nil
+
+	case msg.String() == "pgdown", msg.String() == "ctrl+d":
+		if v.focus == FocusCardList {
+			v.pageCursor(1)
+		}
+		return v, nil
+
+	case msg.String() == "z":
+		if v.focus == FocusCardList {
+			v.centerCursor()
 		}
 		return v, nil
 
@@ -396,7 +883,7 @@ func (v *CardListView) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			v.tagCursor = 0
 			return v, nil
 		case FocusCardList:
-			if len(v.cards) > 0 {
+			if _, ok := v.selectedCard(); ok {
 				v.viewingCard = true
 				return v, v.loadCardComments
 			}
@@ -404,8 +891,8 @@ func (v *CardListView) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case key.Matches(msg, v.keys.Edit):
-		if v.focus == FocusCardList && len(v.cards) > 0 {
-			v.startEditCard(v.cards[v.cursor])
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			v.startEditCard(card)
 			return v, textinput.Blink
 		}
 		return v, nil
@@ -421,12 +908,29 @@ func (v *CardListView) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, textinput.Blink
 
 	case key.Matches(msg, v.keys.Delete):
-		if v.focus == FocusCardList && len(v.cards) > 0 {
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
 			v.confirmingDelete = true
-			v.deleteTargetID = v.cards[v.cursor].Number
-			v.deleteTargetName = v.cards[v.cursor].Title
+			v.deleteTargetID = card.Number
+			v.deleteTargetName = card.Title
+			return v, nil
+		}
+		return v, nil
+
+	case msg.String() == "D":
+		if v.currentColumnID() != "done" {
 			return v, nil
 		}
+		// Archived cards are closed too, but the archive is kept
+		count := 0
+		for _, c := range v.cards {
+			if c.ColumnID == "done" && !v.isArchived(c) {
+				count++
+			}
+		}
+		if count > 0 {
+			v.confirmingClearClosed = true
+			v.clearClosedCount = count
+		}
 		return v, nil
 
 	case msg.String() == "X":
@@ -446,15 +950,148 @@ func (v *CardListView) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		v.focus = FocusTagDropdown
 		v.tagDropdownOpen = true
 		v.tagCursor = 0
-		return v, nil
+		return v, v.loadTagCounts
 
 	case msg.String() == "t":
-		if v.focus == FocusCardList && len(v.cards) > 0 {
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
 			v.assigningTags = true
-			v.assignTagCursor = 0
-			v.assigningCardID = v.cards[v.cursor].Number
+			v.confirmUntag = ""
+			v.assignTagPicker.reset()
+			v.assigningCardID = card.Number
+			return v, nil
+		}
+
+	case msg.String() == "p":
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			v.togglePinned(card.Number)
+		}
+		return v, nil
+
+	case msg.String() == "m":
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			v.startMove(card)
+		}
+		return v, nil
+
+	case msg.String() == "s":
+		next := v.sort
+		next.field = (next.field + 1) % sortField(len(sortFieldNames))
+		v.setSort(next)
+		return v, nil
+
+	case msg.String() == "S":
+		next := v.sort
+		next.desc = !next.desc
+		v.setSort(next)
+		return v, nil
+
+	case msg.String() == "R":
+		v.startRetag()
+		return v, nil
+
+	case msg.String() == "Z":
+		v.showSnoozed = !v.showSnoozed
+		v.cursor = 0
+		v.scrollY = 0
+		return v, nil
+
+	case msg.String() == "Y":
+		v.showShelved = !v.showShelved
+		v.cursor = 0
+		v.scrollY = 0
+		return v, nil
+
+	case msg.String() == "a":
+		if card, ok := v.selectedCard(); ok {
+			v.toggleArchived(card)
+		}
+		return v, nil
+
+	case msg.String() == "V":
+		return v, v.toggleArchiveBrowser()
+
+	case msg.String() == "b":
+		return v, v.openPreviousBoard
+
+	case msg.String() == "H":
+		v.toggleCompletedSection()
+		return v, nil
+
+	case msg.String() == "c":
+		return v, v.cycleClosedDisplay()
+
+	case msg.String() == "G":
+		v.groupByTag = !v.groupByTag
+		v.collapsedGroups = make(map[string]bool)
+		v.cursor = 0
+		v.scrollY = 0
+		return v, nil
+
+	case msg.String() == "-" && v.groupByTag:
+		v.toggleCurrentGroup()
+		return v, nil
+
+	case msg.String() == "+" && v.groupByTag:
+		v.collapsedGroups = make(map[string]bool)
+		v.clampVisibleState()
+		return v, nil
+
+	case msg.String() == "}" && v.groupByTag:
+		v.jumpGroup(1)
+		return v, nil
+
+	case msg.String() == "{" && v.groupByTag:
+		v.jumpGroup(-1)
+		return v, nil
+
+	case msg.String() == "M":
+		v.searchComments = !v.searchComments
+		v.cursor = 0
+		v.scrollY = 0
+		if !v.searchComments {
+			v.commentIndex = nil
 			return v, nil
 		}
+		v.status = "Searching comments too"
+		return v, v.loadCommentIndex()
+
+	case msg.String() == "W":
+		v.showFollowUps = !v.showFollowUps
+		v.cursor = 0
+		v.scrollY = 0
+		return v, nil
+
+	case key.Matches(msg, v.keys.QuickTag):
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			return v, v.toggleQuickTag(card)
+		}
+		return v, nil
+
+	case msg.String() == "x":
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			return v, v.closeCard(card)
+		}
+		return v, nil
+
+	case msg.String() == ".":
+		if card, ok := v.selectedCard(); ok && v.focus == FocusCardList {
+			return v, v.closeAndAdvance(card)
+		}
+		return v, nil
+
+	case msg.String() == "u":
+		return v, v.undoClose()
+
+	case msg.String() == "E":
+		v.startShare(v.filteredCards(), "visible cards")
+		return v, nil
+
+	case msg.String() == ":":
+		return v, func() tea.Msg { return StartJump{} }
+
+	case msg.String() == "A":
+		board := v.board
+		return v, func() tea.Msg { return ShowActivity{Board: board} }
 
 	case msg.String() == "?":
 		v.showHelpPopup = true
@@ -499,17 +1136,14 @@ func (v *CardListView) updateTagDropdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, nil
 
 	case key.Matches(msg, v.keys.Down):
-		if v.tagCursor < len(v.tags) {
+		if v.tagCursor < len(v.tags)+1 {
 			v.tagCursor++
 		}
 		return v, nil
 
 	case key.Matches(msg, v.keys.Enter):
-		if v.tagCursor == 0 {
-			v.selectedTag = ""
-		} else {
-			v.selectedTag = v.tags[v.tagCursor-1].Title
-		}
+		v.selectedTag = v.tagDropdownValue(v.tagCursor)
+		_ = v.settings.Set(tagFilterSettingKey(v.board.ID), v.selectedTag)
 		v.tagDropdownOpen = false
 		v.clampVisibleState()
 		return v, v.loadCards
@@ -520,8 +1154,9 @@ func (v *CardListView) updateTagDropdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (v *CardListView) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "y", "Y":
+	case "y", "Y", "enter":
 		if err := v.fizzy.DeleteCard(v.deleteTargetID); err == nil {
+			v.progressStale = true
 			v.confirmingDelete = false
 			v.viewingCard = false
 			v.viewCardComments = nil
@@ -564,6 +1199,27 @@ func (v *CardListView) updateConfirmDeleteColumn(msg tea.KeyMsg) (tea.Model, tea
 	return v, nil
 }
 
+func (v *CardListView) updateConfirmClearClosed(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		v.confirmingClearClosed = false
+		deleted, err := v.fizzy.DeleteClosedCards(v.board.ID, v.isArchived)
+		v.progressStale = true
+		if err != nil {
+			v.status = fmt.Sprintf("Deleted %d closed cards before failing: %v", deleted, err)
+		} else {
+			v.status = fmt.Sprintf("Deleted %d closed cards", deleted)
+		}
+		v.cursor = 0
+		v.scrollY = 0
+		return v, tea.Batch(v.loadCards, v.loadTagCounts)
+	case "n", "N", "esc":
+		v.confirmingClearClosed = false
+		return v, nil
+	}
+	return v, nil
+}
+
 func (v *CardListView) updateConfirmDiscard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
@@ -616,78 +1272,144 @@ func (v *CardListView) updateViewingCard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	card, ok := v.selectedCard()
+	if !ok {
+		v.viewingCard = false
+		v.viewCardComments = nil
+		return v, nil
+	}
+
 	switch {
 	case key.Matches(msg, v.keys.Back):
 		v.viewingCard = false
 		v.viewCardComments = nil
+		if v.detailChanged {
+			v.detailChanged = false
+			return v, tea.Batch(v.loadCards, v.loadTagCounts)
+		}
 		return v, nil
+	case msg.String() == "x":
+		return v, v.toggleClosedInDetail(card)
 	case key.Matches(msg, v.keys.Edit):
 		v.viewingCard = false
 		v.viewCardComments = nil
-		v.startEditCard(v.cards[v.cursor])
+		v.startEditCard(card)
 		return v, textinput.Blink
 	case key.Matches(msg, v.keys.Delete):
 		v.confirmingDelete = true
-		v.deleteTargetID = v.cards[v.cursor].Number
-		v.deleteTargetName = v.cards[v.cursor].Title
+		v.deleteTargetID = card.Number
+		v.deleteTargetName = card.Title
 		return v, nil
 	case msg.String() == "t":
 		v.viewingCard = false
 		v.viewCardComments = nil
 		v.assigningTags = true
-		v.assignTagCursor = 0
-		v.assigningCardID = v.cards[v.cursor].Number
+		v.confirmUntag = ""
+		v.assignTagPicker.reset()
+		v.assigningCardID = card.Number
 		return v, nil
-	case msg.String() == "c" || msg.String() == "a":
-		v.commentInputFocused = true
-		v.commentInput.Focus()
-		return v, textarea.Blink
-	case key.Matches(msg, v.keys.Quit):
-		return v, tea.Quit
-	}
-	return v, nil
-}
-
-func (v *CardListView) updateAssigningTags(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, v.keys.Back):
-		v.assigningTags = false
+	case msg.String() == "g":
+		return v, v.attachGitHead(card)
+	case msg.String() == "o":
+		v.openExternalRef(card)
 		return v, nil
-
-	case key.Matches(msg, v.keys.Up):
-		if v.assignTagCursor > 0 {
-			v.assignTagCursor--
+	case msg.String() == "E":
+		v.startShare([]models.Card{card}, fmt.Sprintf("#%d", card.Number))
+		return v, nil
+	case msg.String() == "H":
+		v.showCardHistory = !v.showCardHistory
+		return v, nil
+	case msg.String() == "D":
+		v.hideDetailDesc = !v.hideDetailDesc
+		return v, nil
+	case msg.String() == "C":
+		v.hideDetailComments = !v.hideDetailComments
+		return v, nil
+	case msg.String() == "z":
+		return v, v.startSnooze(card)
+	case msg.String() == "Z":
+		if v.isSnoozed(card) {
+			_ = v.settings.UnsnoozeCard(card.Number)
+			v.status = fmt.Sprintf("Unsnoozed #%d", card.Number)
+			v.viewingCard = false
+			v.viewCardComments = nil
+			v.clampVisibleState()
 		}
 		return v, nil
+	case msg.String() == "y":
+		v.toggleShelved(card)
+		return v, nil
+	case msg.String() == "c" || msg.String() == "a":
+		v.commentInputFocused = true
+		v.commentInput.Focus()
+		return v, textarea.Blink
+	case key.Matches(msg, v.keys.Quit):
+		return v, tea.Quit
+	}
+	return v, nil
+}
 
-	case key.Matches(msg, v.keys.Down):
-		if v.assignTagCursor < len(v.tags)-1 {
-			v.assignTagCursor++
+func (v *CardListView) updateAssigningTags(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.confirmUntag != "" {
+		tag := v.confirmUntag
+		switch msg.String() {
+		case "y", "Y", "enter":
+			v.confirmUntag = ""
+			if card, ok := v.selectedCard(); ok {
+				return v, v.setCardTag(card, tag, false)
+			}
+		case "n", "N", "esc":
+			v.confirmUntag = ""
 		}
 		return v, nil
+	}
+
+	if handled, cmd := v.assignTagPicker.update(msg, v.keys, v.tags); handled {
+		return v, cmd
+	}
+
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.assigningTags = false
+		return v, nil
 
 	case key.Matches(msg, v.keys.Enter), msg.String() == " ":
-		if len(v.cards) > 0 && v.assignTagCursor < len(v.tags) {
-			card := v.cards[v.cursor]
-			tag := v.tags[v.assignTagCursor]
-
-			hasTag := false
-			for _, t := range card.Tags {
-				if t == tag.Title {
-					hasTag = true
-					break
-				}
+		card, ok := v.selectedCard()
+		tag, hasMatch := v.assignTagPicker.selected(v.tags)
+		if ok && hasMatch {
+			hasTag := slices.Contains(card.Tags, tag.Title)
+
+			if hasTag && v.untagNeedsConfirm(tag.Title) {
+				v.confirmUntag = tag.Title
+				return v, nil
 			}
 
-			v.fizzy.TagCard(card.Number, tag.Title, hasTag)
-			return v, v.loadCards
+			return v, v.setCardTag(card, tag.Title, !hasTag)
 		}
 	}
 
 	return v, nil
 }
 
+// untagNeedsConfirm reports whether removing tag must be confirmed, per the
+// confirm_untag setting: a comma-separated list of tag names, or "*" for every tag.
+func (v *CardListView) untagNeedsConfirm(tag string) bool {
+	for _, name := range strings.Split(v.settings.Get(confirmUntagSettingKey), ",") {
+		name = strings.TrimSpace(name)
+		if name == "*" || strings.EqualFold(name, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *CardListView) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.editFocusIdx == editFieldTags && msg.String() != "ctrl+s" {
+		if handled, cmd := v.editTagPicker.update(msg, v.keys, v.tags); handled {
+			return v, cmd
+		}
+	}
+
 	switch {
 	case key.Matches(msg, v.keys.Back):
 		if v.hasUnsavedChanges() {
@@ -701,63 +1423,291 @@ func (v *CardListView) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return v, v.saveCard()
 
 	case key.Matches(msg, v.keys.Tab):
-		v.editFocusIdx = (v.editFocusIdx + 1) % 4 // 0-3: title, desc, tags, save
-		v.updateEditFocus()
-		return v, nil
+		return v, v.cycleEditFocus(1)
 
 	case msg.String() == "shift+tab":
-		v.editFocusIdx = (v.editFocusIdx + 3) % 4
-		v.updateEditFocus()
-		return v, nil
+		return v, v.cycleEditFocus(-1)
 
 	case key.Matches(msg, v.keys.Enter):
-		if v.editFocusIdx == 0 {
-			v.editFocusIdx++
-			v.updateEditFocus()
-			return v, nil
-		}
-		if v.editFocusIdx == 2 {
+		switch v.editFocusIdx {
+		case editFieldTitle, editFieldRef, editFieldWaiting, editFieldFollowUp, editFieldRepeat, editFieldBlockedBy, editFieldEstimate:
+			return v, v.cycleEditFocus(1)
+		case editFieldTags:
 			v.toggleEditTag()
 			return v, nil
-		}
-		if v.editFocusIdx == 3 {
+		case editFieldSave:
 			return v, v.saveCard()
 		}
 
 	case msg.String() == " ":
-		if v.editFocusIdx == 2 {
+		if v.editFocusIdx == editFieldTags {
 			v.toggleEditTag()
 			return v, nil
 		}
-
-	case key.Matches(msg, v.keys.Up):
-		if v.editFocusIdx == 2 && v.editTagCursor > 0 {
-			v.editTagCursor--
-			return v, nil
-		}
-
-	case key.Matches(msg, v.keys.Down):
-		if v.editFocusIdx == 2 && v.editTagCursor < len(v.tags)-1 {
-			v.editTagCursor++
-			return v, nil
-		}
 	}
 
 	var cmd tea.Cmd
 	switch v.editFocusIdx {
-	case 0:
+	case editFieldTitle:
 		v.editTitle, cmd = v.editTitle.Update(msg)
-	case 1:
+	case editFieldDesc:
 		v.editDesc, cmd = v.editDesc.Update(msg)
+	case editFieldRef:
+		v.editRef, cmd = v.editRef.Update(msg)
+	case editFieldWaiting:
+		v.editWaiting, cmd = v.editWaiting.Update(msg)
+	case editFieldFollowUp:
+		v.editFollowUpError = ""
+		v.editFollowUp, cmd = v.editFollowUp.Update(msg)
+	case editFieldRepeat:
+		v.editRepeatError = ""
+		v.editRepeat, cmd = v.editRepeat.Update(msg)
+	case editFieldBlockedBy:
+		v.editBlockedError = ""
+		v.editBlockedBy, cmd = v.editBlockedBy.Update(msg)
+	case editFieldEstimate:
+		v.editEstimateError = ""
+		v.editEstimate, cmd = v.editEstimate.Update(msg)
 	}
 	return v, cmd
 }
 
+// toggleClosedInDetail closes or reopens the card being viewed. The loaded copy is
+// updated in place so the detail view stays on this card; the list reloads on return.
+func (v *CardListView) toggleClosedInDetail(card models.Card) tea.Cmd {
+	i := slices.IndexFunc(v.cards, func(c models.Card) bool { return c.Number == card.Number })
+	if i < 0 {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if card.ColumnID == "done" {
+		if err := v.fizzy.ReopenCard(card.Number); err != nil {
+			v.status = fmt.Sprintf("Failed to reopen #%d", card.Number)
+			return nil
+		}
+		if card.Number == v.lastClosedCard {
+			v.unscheduleNext(card.Number)
+		}
+		v.clearClosed(card.Number)
+		v.cards[i].ColumnID = ""
+		v.cards[i].ColumnName = ""
+		v.status = fmt.Sprintf("Reopened #%d", card.Number)
+	} else {
+		if err := v.fizzy.CloseCard(card.Number); err != nil {
+			v.status = fmt.Sprintf("Failed to close #%d", card.Number)
+			return nil
+		}
+		v.cards[i].ColumnID = "done"
+		v.cards[i].ColumnName = "Done"
+		_ = v.settings.SetClosedAt(card.Number, time.Now())
+		_ = v.settings.UnarchiveCard(card.Number)
+		_ = v.settings.UnshelveCard(card.Number)
+		v.lastClosedCard = card.Number
+		v.lastClosedCursor = v.cursor
+		v.lastClosedAt = time.Now()
+		v.status = fmt.Sprintf("Closed #%d", card.Number) + v.scheduleNext(card)
+		cmd = v.completionBell()
+	}
+	v.detailChanged = true
+	v.progressStale = true
+	return cmd
+}
+
+// closeCard closes a card and remembers it so u can reopen it shortly after.
+func (v *CardListView) closeCard(card models.Card) tea.Cmd {
+	if card.ColumnID == "done" {
+		v.status = fmt.Sprintf("#%d is already closed", card.Number)
+		return nil
+	}
+	if err := v.fizzy.CloseCard(card.Number); err != nil {
+		v.status = fmt.Sprintf("Failed to close #%d", card.Number)
+		return nil
+	}
+	_ = v.settings.SetClosedAt(card.Number, time.Now())
+	_ = v.settings.UnarchiveCard(card.Number)
+	_ = v.settings.UnshelveCard(card.Number)
+	v.progressStale = true
+	v.lastClosedCard = card.Number
+	v.lastClosedCursor = v.cursor
+	v.lastClosedAt = time.Now()
+	v.status = fmt.Sprintf("Closed #%d • u to undo", card.Number) + v.scheduleNext(card)
+	return tea.Batch(v.loadCards, v.loadTagCounts, v.completionBell())
+}
+
+// completionBell rings the terminal bell after a card is closed when
+// bell_on_complete is on and STM_NO_BELL is unset.
+func (v *CardListView) completionBell() tea.Cmd {
+	if !v.settings.Bool(bellOnCompleteSettingKey) || os.Getenv("STM_NO_BELL") != "" {
+		return nil
+	}
+	return func() tea.Msg {
+		// BEL draws nothing, so writing it past the renderer can't disturb the screen
+		_, _ = os.Stdout.WriteString("\a")
+		return nil
+	}
+}
+
+// closeAndAdvance closes card and, once the list reloads, puts the cursor on
+// the card that followed it, so a list can be triaged top to bottom.
+func (v *CardListView) closeAndAdvance(card models.Card) tea.Cmd {
+	cmd := v.closeCard(card)
+	if cmd == nil {
+		return nil
+	}
+	if filtered := v.filteredCards(); v.cursor+1 < len(filtered) {
+		v.pendingSelectCard = filtered[v.cursor+1].Number
+	}
+	return cmd
+}
+
+// undoClose reopens the last card closed with x, if it was closed within undoCloseWindow.
+func (v *CardListView) undoClose() tea.Cmd {
+	if v.lastClosedCard == 0 || time.Since(v.lastClosedAt) > undoCloseWindow {
+		v.lastClosedCard = 0
+		v.status = "Nothing to undo"
+		return nil
+	}
+	number := v.lastClosedCard
+	v.lastClosedCard = 0
+	if err := v.fizzy.ReopenCard(number); err != nil {
+		v.status = fmt.Sprintf("Failed to reopen #%d", number)
+		return nil
+	}
+	v.unscheduleNext(number)
+	v.clearClosed(number)
+	v.progressStale = true
+	v.pendingSelectCard = number
+	v.status = fmt.Sprintf("Reopened #%d", number)
+	return tea.Batch(v.loadCards, v.loadTagCounts)
+}
+
+// toggleQuickTag toggles the tag configured under the "quick_tag" setting on a card.
+// It does nothing when no quick tag is configured or the tag no longer exists.
+func (v *CardListView) toggleQuickTag(card models.Card) tea.Cmd {
+	name := strings.TrimSpace(v.settings.Get(quickTagSettingKey))
+	if name == "" {
+		return nil
+	}
+
+	var tag *models.Tag
+	for i := range v.tags {
+		if strings.EqualFold(v.tags[i].Title, name) {
+			tag = &v.tags[i]
+			break
+		}
+	}
+	if tag == nil {
+		v.status = fmt.Sprintf("Quick tag %q not found", name)
+		return nil
+	}
+
+	return v.setCardTag(card, tag.Title, !slices.Contains(card.Tags, tag.Title))
+}
+
+// setCardTag adds or removes a tag on card. It reloads only when the card
+// changed, and says so in the status line when it was already that way.
+func (v *CardListView) setCardTag(card models.Card, tag string, on bool) tea.Cmd {
+	var changed bool
+	var err error
+	if on {
+		changed, err = v.fizzy.AddTag(&card, tag)
+	} else {
+		changed, err = v.fizzy.RemoveTag(&card, tag)
+	}
+	switch {
+	case err != nil:
+		v.status = "Failed to update tag " + tag
+		return nil
+	case !changed && on:
+		v.status = fmt.Sprintf("#%d is already tagged %s", card.Number, tag)
+		return nil
+	case !changed:
+		v.status = fmt.Sprintf("#%d isn't tagged %s", card.Number, tag)
+		return nil
+	}
+	return v.loadCards
+}
+
+type previousBoardMissingMsg struct{}
+
+// openPreviousBoard switches to the board that was open before this one.
+func (v *CardListView) openPreviousBoard() tea.Msg {
+	id := v.settings.Get(PreviousBoardSettingKey)
+	if id == "" || id == v.board.ID {
+		return previousBoardMissingMsg{}
+	}
+	boards, err := v.fizzy.ListBoards()
+	if err != nil {
+		return err
+	}
+	for _, b := range boards {
+		if b.ID == id {
+			return SelectedBoard{Board: b}
+		}
+	}
+	return previousBoardMissingMsg{}
+}
+
+// matchesExtra checks search text against what fizzy does not store on the card:
+// its external reference and, when comment search is on, its comments.
+func (v *CardListView) matchesExtra(query searchQuery, c models.Card) bool {
+	if ref := v.settings.ExternalRef(c.Number); ref != "" && query.matchesAny([]string{strings.ToLower(ref)}) {
+		return true
+	}
+	return v.searchComments && query.matchesAny(v.commentIndex[c.Number])
+}
+
+// openExternalRef opens a card's reference in the browser when it is a URL.
+func (v *CardListView) openExternalRef(card models.Card) {
+	ref := v.settings.ExternalRef(card.Number)
+	if !strings.HasPrefix(ref, "http://") && !strings.HasPrefix(ref, "https://") {
+		v.status = "Reference is not a URL"
+		return
+	}
+	if err := opener.Open(ref); err != nil {
+		v.status = "Failed to open " + ref
+		return
+	}
+	v.status = "Opened " + ref
+}
+
+// followUpDue reports whether a waiting card's follow-up date has arrived.
+func (v *CardListView) followUpDue(card models.Card) bool {
+	waitingOn, followUp := v.settings.Waiting(card.Number)
+	return waitingOn != "" && !followUp.IsZero() && !time.Now().Before(followUp)
+}
+
+// waitingBadge renders "⏳ waiting on X" for the list, highlighted once the follow-up is due.
+func (v *CardListView) waitingBadge(card models.Card) string {
+	waitingOn, _ := v.settings.Waiting(card.Number)
+	if waitingOn == "" {
+		return ""
+	}
+	style := v.styles.TitleMuted
+	if v.followUpDue(card) {
+		style = lipgloss.NewStyle().Foreground(styles.Current.Warning)
+	}
+	return style.Render("⏳ waiting on " + waitingOn)
+}
+
+func (v *CardListView) waitingSummary(card models.Card) string {
+	waitingOn, followUp := v.settings.Waiting(card.Number)
+	if waitingOn == "" {
+		return v.styles.TitleMuted.Render("Nothing")
+	}
+	if followUp.IsZero() {
+		return waitingOn
+	}
+	return fmt.Sprintf("%s (follow up %s)", waitingOn, followUp.Format("Jan 2, 2006"))
+}
+
 func (v *CardListView) toggleEditTag() {
-	if v.editTagCursor >= len(v.tags) {
+	tag, ok := v.editTagPicker.selected(v.tags)
+	if !ok {
 		return
 	}
-	tagTitle := v.tags[v.editTagCursor].Title
+	tagTitle := tag.Title
 
 	for i, t := range v.editTags {
 		if t == tagTitle {
@@ -776,18 +1726,60 @@ func (v *CardListView) cycleFocus(dir int) {
 	}
 }
 
-func (v *CardListView) ensureVisible() {
-	availableHeight := v.height - 10
-	if availableHeight < 2 {
-		availableHeight = 2
+// moveCursor moves the card cursor by delta, clamping at the ends of the list
+// or wrapping around when the wrap_navigation setting is on.
+func (v *CardListView) moveCursor(delta int) {
+	count := len(v.filteredCards())
+	if count == 0 {
+		return
+	}
+
+	next := v.cursor + delta
+	if v.settings.Bool(wrapNavigationSettingKey) {
+		next = (next%count + count) % count
+	} else {
+		next = clamp(next, 0, count-1)
+	}
+	v.cursor = next
+	v.ensureVisible()
+}
+
+// pageCursor moves the cursor a screenful of cards up (dir < 0) or down, stopping at the ends.
+func (v *CardListView) pageCursor(dir int) {
+	count := len(v.filteredCards())
+	if count == 0 {
+		return
 	}
-	visibleItems := availableHeight / 2
-	if visibleItems < 1 {
-		visibleItems = 1
+	v.cursor = clamp(v.cursor+dir*v.visibleItems(), 0, count-1)
+	v.ensureVisible()
+}
+
+// visibleItems is how many cards fit in the list area below the header.
+func (v *CardListView) visibleItems() int {
+	if v.settings.Bool(compactRowsSettingKey) {
+		// One line per row, plus the selected row's tag line
+		return max(v.height-13, 1)
 	}
+	return max((v.height-12)/2, 1)
+}
+
+// centerCursor scrolls so the cursor sits mid-list, as far as the ends allow.
+func (v *CardListView) centerCursor() {
+	visibleItems := v.visibleItems()
+	maxScroll := max(len(v.filteredCards())-visibleItems, 0)
+	v.scrollY = clamp(v.cursor-visibleItems/2, 0, maxScroll)
+}
+
+func (v *CardListView) ensureVisible() {
+	visibleItems := v.visibleItems()
 
 	if v.cursor < v.scrollY {
 		v.scrollY = v.cursor
+	} else if v.groupByTag {
+		// Group headers take rows too, so fewer cards fit than visibleItems
+		for v.cursor >= v.scrollY+v.groupedCardsFitting(v.scrollY) {
+			v.scrollY++
+		}
 	} else if v.cursor >= v.scrollY+visibleItems {
 		v.scrollY = v.cursor - visibleItems + 1
 	}
@@ -796,33 +1788,71 @@ func (v *CardListView) ensureVisible() {
 func (v *CardListView) startNewCard() {
 	v.editing = true
 	v.editingNew = true
-	v.editFocusIdx = 0
-	v.editTagCursor = 0
+	v.editFocusIdx = editFieldTitle
+	v.editTagPicker.reset()
 	v.editTags = []string{}
 	v.editTitle.Reset()
 	v.editDesc.Reset()
+	v.editRef.Reset()
+	v.editWaiting.Reset()
+	v.editFollowUp.Reset()
+	v.editFollowUpError = ""
+	v.editRepeat.Reset()
+	v.editRepeatError = ""
+	v.editBlockedBy.Reset()
+	v.editBlockedError = ""
+	v.editEstimate.Reset()
+	v.editEstimateError = ""
 	v.updateEditFocus()
 
 	v.originalTitle = ""
 	v.originalDesc = ""
 	v.originalTags = []string{}
+	v.originalRef = ""
+	v.originalWaiting = ""
+	v.originalFollowUp = ""
+	v.originalRepeat = ""
+	v.originalBlockedBy = ""
+	v.originalEstimate = ""
 }
 
 func (v *CardListView) startEditCard(card models.Card) {
 	v.editing = true
 	v.editingNew = false
-	v.editFocusIdx = 0
-	v.editTagCursor = 0
+	v.editFocusIdx = editFieldTitle
+	v.editTagPicker.reset()
 	v.editTags = make([]string, len(card.Tags))
 	copy(v.editTags, card.Tags)
 	v.editTitle.SetValue(card.Title)
 	v.editDesc.SetValue(card.Description)
+
+	waitingOn, followUp := v.settings.Waiting(card.Number)
+	followUpText := ""
+	if !followUp.IsZero() {
+		followUpText = followUp.Format("2006-01-02")
+	}
+	v.editRef.SetValue(v.settings.ExternalRef(card.Number))
+	v.editWaiting.SetValue(waitingOn)
+	v.editFollowUp.SetValue(followUpText)
+	v.editFollowUpError = ""
+	v.editRepeat.SetValue(v.settings.Recurrence(card.Number))
+	v.editRepeatError = ""
+	v.editBlockedBy.SetValue(formatBlockers(v.settings.BlockedBy(card.Number)))
+	v.editBlockedError = ""
+	v.editEstimate.SetValue(formatEstimate(v.settings.Estimate(card.Number)))
+	v.editEstimateError = ""
 	v.updateEditFocus()
 
 	v.originalTitle = card.Title
 	v.originalDesc = card.Description
 	v.originalTags = make([]string, len(card.Tags))
 	copy(v.originalTags, card.Tags)
+	v.originalRef = v.editRef.Value()
+	v.originalWaiting = waitingOn
+	v.originalFollowUp = followUpText
+	v.originalRepeat = v.editRepeat.Value()
+	v.originalBlockedBy = v.editBlockedBy.Value()
+	v.originalEstimate = v.editEstimate.Value()
 }
 
 func (v *CardListView) hasUnsavedChanges() bool {
@@ -832,6 +1862,18 @@ func (v *CardListView) hasUnsavedChanges() bool {
 	if v.editDesc.Value() != v.originalDesc {
 		return true
 	}
+	if v.editRef.Value() != v.originalRef {
+		return true
+	}
+	if v.editWaiting.Value() != v.originalWaiting || v.editFollowUp.Value() != v.originalFollowUp {
+		return true
+	}
+	if v.editRepeat.Value() != v.originalRepeat || v.editBlockedBy.Value() != v.originalBlockedBy {
+		return true
+	}
+	if v.editEstimate.Value() != v.originalEstimate {
+		return true
+	}
 	if len(v.editTags) != len(v.originalTags) {
 		return true
 	}
@@ -843,16 +1885,79 @@ func (v *CardListView) hasUnsavedChanges() bool {
 	return false
 }
 
-func (v *CardListView) updateEditFocus() {
+// editFields returns the edit form fields in tab order, leaving out the
+// optional ones listed in the hidden_edit_fields setting.
+func (v *CardListView) editFields() []int {
+	hidden := make(map[string]bool)
+	for _, name := range strings.Split(v.settings.Get(hiddenEditFieldsSettingKey), ",") {
+		hidden[strings.TrimSpace(name)] = true
+	}
+
+	fields := []int{editFieldTitle, editFieldDesc}
+	for _, f := range []struct {
+		name  string
+		field int
+	}{
+		{"ref", editFieldRef},
+		{"waiting", editFieldWaiting},
+		{"follow_up", editFieldFollowUp},
+		{"repeat", editFieldRepeat},
+		{"blocked_by", editFieldBlockedBy},
+		{"estimate", editFieldEstimate},
+		{"tags", editFieldTags},
+	} {
+		if !hidden[f.name] {
+			fields = append(fields, f.field)
+		}
+	}
+	return append(fields, editFieldSave)
+}
+
+func (v *CardListView) editFieldEnabled(field int) bool {
+	return slices.Contains(v.editFields(), field)
+}
+
+// cycleEditFocus moves focus delta fields through the enabled fields, wrapping at either end.
+func (v *CardListView) cycleEditFocus(delta int) tea.Cmd {
+	fields := v.editFields()
+	idx := max(slices.Index(fields, v.editFocusIdx), 0)
+	v.editFocusIdx = fields[(idx+delta+len(fields))%len(fields)]
+	return v.updateEditFocus()
+}
+
+// updateEditFocus moves focus to the current edit field. Blur and Focus leave
+// each input's text, cursor and scroll alone, so tabbing back into the
+// description returns to the same spot; the returned command restarts the
+// cursor blink so that spot is visible straight away.
+func (v *CardListView) updateEditFocus() tea.Cmd {
 	v.editTitle.Blur()
 	v.editDesc.Blur()
+	v.editRef.Blur()
+	v.editWaiting.Blur()
+	v.editFollowUp.Blur()
+	v.editRepeat.Blur()
+	v.editBlockedBy.Blur()
+	v.editEstimate.Blur()
 
 	switch v.editFocusIdx {
-	case 0:
-		v.editTitle.Focus()
-	case 1:
-		v.editDesc.Focus()
-	}
+	case editFieldTitle:
+		return v.editTitle.Focus()
+	case editFieldDesc:
+		return v.editDesc.Focus()
+	case editFieldRef:
+		return v.editRef.Focus()
+	case editFieldWaiting:
+		return v.editWaiting.Focus()
+	case editFieldFollowUp:
+		return v.editFollowUp.Focus()
+	case editFieldRepeat:
+		return v.editRepeat.Focus()
+	case editFieldBlockedBy:
+		return v.editBlockedBy.Focus()
+	case editFieldEstimate:
+		return v.editEstimate.Focus()
+	}
+	return nil
 }
 
 func (v *CardListView) saveCard() tea.Cmd {
@@ -863,6 +1968,49 @@ func (v *CardListView) saveCard() tea.Cmd {
 	}
 
 	desc := strings.TrimSpace(v.editDesc.Value())
+	ref := strings.TrimSpace(v.editRef.Value())
+	waitingOn := strings.TrimSpace(v.editWaiting.Value())
+
+	// Validate the follow-up date before writing anything so a typo keeps the form open
+	var followUp time.Time
+	if text := strings.TrimSpace(v.editFollowUp.Value()); text != "" {
+		parsed, err := dates.ParseRelative(text, time.Now())
+		if err != nil {
+			v.editFollowUpError = err.Error()
+			v.editFocusIdx = editFieldFollowUp
+			v.updateEditFocus()
+			return nil
+		}
+		followUp = parsed
+	}
+	repeat := strings.ToLower(strings.TrimSpace(v.editRepeat.Value()))
+	if repeat != "" {
+		if _, err := dates.NextRecurrence(repeat, time.Now()); err != nil {
+			v.editRepeatError = err.Error()
+			v.editFocusIdx = editFieldRepeat
+			v.updateEditFocus()
+			return nil
+		}
+	}
+	blockers, err := parseBlockers(v.editBlockedBy.Value())
+	if err == nil && !v.editingNew {
+		if card, ok := v.selectedCard(); ok {
+			err = v.checkBlockers(card.Number, blockers)
+		}
+	}
+	if err != nil {
+		v.editBlockedError = err.Error()
+		v.editFocusIdx = editFieldBlockedBy
+		v.updateEditFocus()
+		return nil
+	}
+	estimate, err := parseEstimate(v.editEstimate.Value())
+	if err != nil {
+		v.editEstimateError = err.Error()
+		v.editFocusIdx = editFieldEstimate
+		v.updateEditFocus()
+		return nil
+	}
 
 	if v.editingNew {
 		card, err := v.fizzy.CreateCard(v.board.ID, title, desc)
@@ -870,38 +2018,32 @@ func (v *CardListView) saveCard() tea.Cmd {
 			v.editing = false
 			return nil
 		}
+		v.progressStale = true
 		// Apply tags
 		for _, tagTitle := range v.editTags {
-			v.fizzy.TagCard(card.Number, tagTitle, false)
+			v.fizzy.AddTag(card, tagTitle)
 		}
-	} else if len(v.cards) > 0 {
-		card := v.cards[v.cursor]
+		_ = v.settings.SetExternalRef(card.Number, ref)
+		_ = v.settings.SetWaiting(card.Number, waitingOn, followUp)
+		_ = v.settings.SetRecurrence(card.Number, repeat)
+		_ = v.settings.SetBlockedBy(card.Number, blockers)
+		_ = v.settings.SetEstimate(card.Number, estimate)
+	} else if card, ok := v.selectedCard(); ok {
 		v.fizzy.UpdateCard(card.Number, title, desc)
+		_ = v.settings.SetExternalRef(card.Number, ref)
+		_ = v.settings.SetWaiting(card.Number, waitingOn, followUp)
+		_ = v.settings.SetRecurrence(card.Number, repeat)
+		_ = v.settings.SetBlockedBy(card.Number, blockers)
+		_ = v.settings.SetEstimate(card.Number, estimate)
 
 		// Sync tags - remove old, add new
-		for _, existingTag := range card.Tags {
-			found := false
-			for _, selected := range v.editTags {
-				if existingTag == selected {
-					found = true
-					break
-				}
-			}
-			if !found {
-				v.fizzy.TagCard(card.Number, existingTag, true)
+		for _, existingTag := range slices.Clone(card.Tags) {
+			if !slices.Contains(v.editTags, existingTag) {
+				v.fizzy.RemoveTag(&card, existingTag)
 			}
 		}
 		for _, selected := range v.editTags {
-			found := false
-			for _, existingTag := range card.Tags {
-				if existingTag == selected {
-					found = true
-					break
-				}
-			}
-			if !found {
-				v.fizzy.TagCard(card.Number, selected, false)
-			}
+			v.fizzy.AddTag(&card, selected)
 		}
 	}
 
@@ -936,12 +2078,12 @@ func (v *CardListView) submitComment() tea.Cmd {
 		return nil
 	}
 
-	if len(v.cards) == 0 || v.cursor >= len(v.cards) {
+	card, ok := v.selectedCard()
+	if !ok {
 		return nil
 	}
 
-	cardNumber := v.cards[v.cursor].Number
-	_, err := v.fizzy.CreateComment(cardNumber, content)
+	_, err := v.fizzy.CreateComment(card.Number, content)
 	if err != nil {
 		return nil
 	}
@@ -949,17 +2091,54 @@ func (v *CardListView) submitComment() tea.Cmd {
 	v.commentInput.Reset()
 	v.commentInputFocused = false
 	v.commentInput.Blur()
+	delete(v.commentIndex, card.Number)
 
 	return v.loadCardComments
 }
 
+// attachGitHead records the working directory's git branch and commit as a comment on the card.
+func (v *CardListView) attachGitHead(card models.Card) tea.Cmd {
+	branch, commit, err := gitinfo.Head()
+	if err != nil {
+		v.status = "No git info: " + err.Error()
+		return nil
+	}
+
+	body := "git: " + branch
+	if commit != "" {
+		body += " @ " + commit
+	}
+	if _, err := v.fizzy.CreateComment(card.Number, body); err != nil {
+		v.status = "Failed to add git comment"
+		return nil
+	}
+	v.status = "Attached " + body
+	delete(v.commentIndex, card.Number)
+	return v.loadCardComments
+}
+
+// exportToFile writes cards as a markdown file in the working directory and returns a status message.
+func (v *CardListView) exportToFile(cards []models.Card) string {
+	path := export.FileName(v.board, "md")
+	f, err := os.Create(path)
+	if err != nil {
+		return "Export failed: " + err.Error()
+	}
+	defer f.Close()
+
+	if err := export.Markdown(f, v.board, cards); err != nil {
+		return "Export failed: " + err.Error()
+	}
+	return fmt.Sprintf("Exported %d cards to %s", len(cards), path)
+}
+
 func (v *CardListView) loadCardComments() tea.Msg {
-	if len(v.cards) == 0 || v.cursor >= len(v.cards) {
+	card, ok := v.selectedCard()
+	if !ok {
 		return nil
 	}
 
-	cardNumber := v.cards[v.cursor].Number
-	comments, err := v.fizzy.ListComments(cardNumber)
+	comments, err := v.fizzy.ListComments(card.Number)
 	if err != nil {
 		return nil
 	}
@@ -972,6 +2151,30 @@ type commentsLoadedMsg struct {
 
 // View renders the card list view
 func (v *CardListView) View() string {
+	if sub := v.renderSubMode(); sub != "" {
+		return v.withContextLine(sub)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(v.renderHeader())
+	b.WriteString("\n\n")
+
+	b.WriteString(v.renderCardList())
+
+	b.WriteString("\n")
+	if v.status != "" {
+		b.WriteString(v.styles.StatusBar.Render(v.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(v.renderHelp())
+
+	return styles.CenterView(b.String(), v.width, v.height)
+}
+
+// renderSubMode renders whichever prompt, form or card detail is open, or ""
+// when the card list itself is showing.
+func (v *CardListView) renderSubMode() string {
 	if v.showHelpPopup {
 		return v.renderHelpPopup()
 	}
@@ -984,6 +2187,10 @@ func (v *CardListView) View() string {
 		return v.renderDeleteColumnConfirm()
 	}
 
+	if v.confirmingClearClosed {
+		return v.renderClearClosedConfirm()
+	}
+
 	if v.confirmingDiscard {
 		return v.renderDiscardConfirm()
 	}
@@ -996,6 +2203,14 @@ func (v *CardListView) View() string {
 		return v.renderEditForm()
 	}
 
+	if v.snoozing {
+		return v.renderSnoozePrompt()
+	}
+
+	if v.sharing {
+		return v.renderShareMenu()
+	}
+
 	if v.viewingCard {
 		return v.renderCardView()
 	}
@@ -1004,17 +2219,58 @@ func (v *CardListView) View() string {
 		return v.renderTagAssignment()
 	}
 
-	var b strings.Builder
+	if v.retagging {
+		return v.renderRetag()
+	}
 
-	b.WriteString(v.renderHeader())
-	b.WriteString("\n\n")
+	if v.moving {
+		return v.renderMovePicker()
+	}
 
-	b.WriteString(v.renderCardList())
+	return ""
+}
 
-	b.WriteString("\n")
-	b.WriteString(v.renderHelp())
+// withContextLine puts the board and filter summary on the top line of a
+// sub-mode screen, so the board stays in view while editing or reading a card.
+// Popups are vertically centered and start blank, so the line normally takes
+// the place of an empty row; otherwise it pushes the screen down one row.
+func (v *CardListView) withContextLine(screen string) string {
+	contentWidth := styles.ContentWidth(v.width)
+	if contentWidth < 40 {
+		return screen
+	}
 
-	return styles.CenterView(b.String(), v.width, v.height)
+	parts := []string{v.board.Name}
+	if v.currentColumn > 0 {
+		parts = append(parts, v.currentColumnName())
+	}
+	summary := strings.Join(parts, " › ")
+	if v.selectedTag != "" {
+		summary += " • tag: " + v.tagFilterLabel()
+	}
+	if query := strings.TrimSpace(v.searchInput.Value()); query != "" {
+		summary += " • search: " + query
+	}
+
+	indent := strings.Repeat(" ", (v.width-contentWidth)/2)
+	line := indent + v.styles.TitleMuted.Render(truncateLine(summary, contentWidth))
+
+	first, rest, _ := strings.Cut(screen, "\n")
+	if strings.TrimSpace(first) == "" {
+		return line + "\n" + rest
+	}
+	return line + "\n" + screen
+}
+
+// tagFilterLabel names the active tag filter as shown in the header
+func (v *CardListView) tagFilterLabel() string {
+	switch v.selectedTag {
+	case "":
+		return "All"
+	case untaggedFilter:
+		return "Untagged"
+	}
+	return v.selectedTag
 }
 
 func (v *CardListView) renderHeader() string {
@@ -1034,17 +2290,44 @@ func (v *CardListView) renderHeader() string {
 	if v.focus == FocusTagDropdown {
 		tagStyle = s.ButtonFocused
 	}
-	tagLabel := "All"
-	if v.selectedTag != "" {
-		tagLabel = v.selectedTag
-	}
+	tagLabel := v.tagFilterLabel()
 	if !isNarrow {
 		tagLabel = "Tags: " + tagLabel
 	}
 	tagBtn := tagStyle.Render(tagLabel + " ▼")
 
 	titleText := v.board.Name
-	title := s.Title.Render(titleText)
+	title := s.Title.Render(titleText) + "  " + s.TitleMuted.Render("sort: "+v.sort.Label())
+	if v.showSnoozed {
+		title += s.TitleMuted.Render(" • snoozed")
+	}
+	if v.showShelved {
+		title += s.TitleMuted.Render(" • shelf")
+	}
+	if v.showFollowUps {
+		title += s.TitleMuted.Render(" • follow-ups due")
+	}
+	if v.showArchived {
+		title += s.TitleMuted.Render(" • archive")
+	}
+	if d := v.closedDisplayActive(); d != closedHidden {
+		title += s.TitleMuted.Render(" • closed: " + d.String())
+	}
+	if v.searchComments {
+		title += s.TitleMuted.Render(" • +comments")
+	}
+	if v.groupByTag {
+		title += s.TitleMuted.Render(" • by tag")
+	}
+	if badge := boardBadge(v.settings, v.board); badge != "" {
+		title = badge + " " + title
+	}
+	if bar := renderProgressBar(s, v.progress); bar != "" {
+		title += "  " + bar
+	}
+	if badge := renderRecentBadge(v.progress); badge != "" {
+		title += "  " + badge
+	}
 
 	// Column indicator
 	columnBar := v.renderColumnBar()
@@ -1097,22 +2380,54 @@ func (v *CardListView) renderColumnBar() string {
 	return lipgloss.JoinHorizontal(lipgloss.Left, items...)
 }
 
+// previewTagFilter counts the cards the list would show with tag as the filter,
+// keeping the current search and view toggles.
+func (v *CardListView) previewTagFilter(tag string) int {
+	current := v.selectedTag
+	v.selectedTag = tag
+	count := len(v.filteredCards())
+	v.selectedTag = current
+	return count
+}
+
+// tagDropdownValue maps a tag dropdown row to the selectedTag it applies:
+// None, then Untagged, then each tag.
+func (v *CardListView) tagDropdownValue(row int) string {
+	switch {
+	case row == 0:
+		return ""
+	case row == 1:
+		return untaggedFilter
+	case row-2 < len(v.tags):
+		return v.tags[row-2].Title
+	}
+	return ""
+}
+
 func (v *CardListView) renderTagDropdown() string {
 	s := v.styles
 	var items []string
 
-	noneStyle := s.ListItem
-	if v.tagCursor == 0 {
-		noneStyle = s.ListSelected
+	for i, label := range []string{"None", "Untagged"} {
+		itemStyle := s.ListItem
+		if v.tagCursor == i {
+			itemStyle = s.ListSelected
+			label += fmt.Sprintf(" → %d shown", v.previewTagFilter(v.tagDropdownValue(i)))
+		}
+		items = append(items, itemStyle.Render(label))
 	}
-	items = append(items, noneStyle.Render("None"))
 
 	for i, tag := range v.tags {
 		itemStyle := s.ListItem
-		if v.tagCursor == i+1 {
+		label := tagLabel(tag.Title)
+		if v.tagCounts != nil {
+			label = fmt.Sprintf("%s (%d)", label, v.tagCounts[tag.Title])
+		}
+		if v.tagCursor == i+2 {
 			itemStyle = s.ListSelected
+			label += fmt.Sprintf(" → %d shown", v.previewTagFilter(tag.Title))
 		}
-		items = append(items, itemStyle.Render(tag.Title))
+		items = append(items, itemStyle.Render(label))
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, items...)
@@ -1126,27 +2441,45 @@ func (v *CardListView) renderCardList() string {
 		return s.TitleMuted.Render("Loading...")
 	}
 
-	filtered := v.filteredCards()
-	if len(filtered) == 0 {
-		return s.TitleMuted.Render("No cards. Press 'n' to create one.")
+	if v.groupByTag {
+		// Collapsed groups still show their headers when no cards are visible
+		if items := v.renderGroupedList(); len(items) > 0 {
+			return lipgloss.JoinVertical(lipgloss.Left, items...)
+		}
 	}
 
-	availableHeight := v.height - 12
-	if availableHeight < 2 {
-		availableHeight = 2
+	filtered := v.filteredCards()
+
+	// With the Completed section on, closed cards follow the open ones under a header
+	firstClosed, closedCount := -1, 0
+	if v.completedSectionActive() {
+		open, closed := partitionClosed(v.matchingCards())
+		closedCount = len(closed)
+		if v.completedExpanded && closedCount > 0 {
+			firstClosed = len(open)
+		}
 	}
-	visibleItems := availableHeight / 2
-	if visibleItems < 1 {
-		visibleItems = 1
+
+	if len(filtered) == 0 {
+		if closedCount > 0 {
+			return v.renderCompletedHeader(closedCount)
+		}
+		return s.TitleMuted.Render("No cards. Press 'n' to create one.")
 	}
 
 	var items []string
-	endIdx := min(v.scrollY+visibleItems, len(filtered))
+	endIdx := min(v.scrollY+v.visibleItems(), len(filtered))
 
 	for i := v.scrollY; i < endIdx; i++ {
+		if i == firstClosed {
+			items = append(items, v.renderCompletedHeader(closedCount))
+		}
 		card := filtered[i]
 		items = append(items, v.renderCardItem(card, i == v.cursor && v.focus == FocusCardList))
 	}
+	if !v.completedExpanded && closedCount > 0 && endIdx == len(filtered) {
+		items = append(items, v.renderCompletedHeader(closedCount))
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, items...)
 }
@@ -1157,14 +2490,49 @@ func (v *CardListView) renderCardItem(card models.Card, selected bool) string {
 	width := max(contentWidth-4, 20)
 
 	// Title with card number
-	titleLine := fmt.Sprintf("#%d %s", card.Number, card.Title)
+	titleLine := card.Title
+	if !v.settings.Bool(hideCardNumbersSettingKey) {
+		titleLine = s.TitleMuted.Render(fmt.Sprintf("#%d", card.Number)) + " " + titleLine
+	}
+	if v.pinned[card.Number] {
+		titleLine = "📌 " + titleLine
+	}
+	if until, snoozed := v.settings.SnoozedUntil(card.Number, time.Now()); snoozed {
+		titleLine += " " + s.TitleMuted.Render("💤 "+until.Format("Jan 2"))
+	}
+	if v.isShelved(card) {
+		titleLine += " " + s.TitleMuted.Render("shelved")
+	}
+	if badge := v.waitingBadge(card); badge != "" {
+		titleLine += " " + badge
+	}
+	if ref := v.settings.ExternalRef(card.Number); ref != "" {
+		titleLine += " " + s.TitleMuted.Render("🔗 "+ref)
+	}
+	if rule := v.settings.Recurrence(card.Number); rule != "" {
+		titleLine += " " + s.TitleMuted.Render("🔁 "+rule)
+	}
+	if badge := v.blockedBadge(card); badge != "" {
+		titleLine += " " + badge
+	}
+	if v.searchComments {
+		if q := parseSearchQuery(v.searchInput.Value()); q.text != "" && !q.matchesText(card) &&
+			q.matchesAny(v.commentIndex[card.Number]) {
+			titleLine += " " + s.TitleMuted.Render("💬 in comments")
+		}
+	}
 
-	// Tags line
-	var tagsLine string
-	if len(card.Tags) > 0 {
-		tagsLine = strings.Join(card.Tags, " ")
-	} else {
-		tagsLine = s.TitleMuted.Render("no tags")
+	showSnippet := v.settings.Bool(showDescriptionsSettingKey)
+	if card.Description != "" && !showSnippet && !v.settings.Bool(hideDescriptionGlyphSettingKey) {
+		titleLine += " " + s.TitleMuted.Render("📝")
+	}
+
+	tagsLine := renderTags(s, card.Tags)
+	if showSnippet && card.Description != "" {
+		// Share the tag line so rows keep their two-line height. The row's
+		// padding (2 each side) and the gap before the snippet come out of width.
+		room := width - lipgloss.Width(tagsLine) - 6
+		tagsLine += "  " + s.TitleMuted.Render(truncateLine(card.Description, room))
 	}
 
 	var titleStyle, tagLineStyle lipgloss.Style
@@ -1176,7 +2544,22 @@ func (v *CardListView) renderCardItem(card models.Card, selected bool) string {
 		tagLineStyle = s.ListItem.Width(width)
 	}
 
+	if color, ok := styles.RowColorForTags(card.Tags, tagColorRules(v.settings)); ok {
+		titleStyle = titleStyle.Foreground(color)
+	}
+	if card.ColumnID == "done" && v.closedDisplayActive() == closedDimmed {
+		titleStyle = titleStyle.Faint(true)
+		tagLineStyle = tagLineStyle.Faint(true)
+	}
+
 	title := titleStyle.Render(titleLine)
+	if v.settings.Bool(compactRowsSettingKey) {
+		// Only the selected row opens up to show its tag line
+		if !selected {
+			return title
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, title, tagLineStyle.Render(tagsLine))
+	}
 	tags := tagLineStyle.Render(tagsLine)
 
 	return lipgloss.JoinVertical(lipgloss.Left, title, tags) + "\n"
@@ -1193,24 +2576,58 @@ func (v *CardListView) renderEditForm() string {
 
 	titleStyle := s.Input
 	descStyle := s.Input
+	refStyle := s.Input
+	waitingStyle := s.Input
+	followUpStyle := s.Input
+	repeatStyle := s.Input
+	blockedStyle := s.Input
+	estimateStyle := s.Input
 	tagsStyle := s.Input
 	btnStyle := s.Button
 
 	switch v.editFocusIdx {
-	case 0:
+	case editFieldTitle:
 		titleStyle = s.InputFocused
-	case 1:
+	case editFieldDesc:
 		descStyle = s.InputFocused
-	case 2:
+	case editFieldRef:
+		refStyle = s.InputFocused
+	case editFieldWaiting:
+		waitingStyle = s.InputFocused
+	case editFieldFollowUp:
+		followUpStyle = s.InputFocused
+	case editFieldRepeat:
+		repeatStyle = s.InputFocused
+	case editFieldBlockedBy:
+		blockedStyle = s.InputFocused
+	case editFieldEstimate:
+		estimateStyle = s.InputFocused
+	case editFieldTags:
 		tagsStyle = s.InputFocused
-	case 3:
+	case editFieldSave:
 		btnStyle = s.ButtonFocused
 	}
 
 	inputWidth := clamp(contentWidth-6, 20, 50)
-	tagSelector := v.renderEditTagSelector(tagsStyle, inputWidth)
 
-	form := lipgloss.JoinVertical(lipgloss.Left,
+	followUpLabel := "Follow up:"
+	if v.editFollowUpError != "" {
+		followUpLabel += " " + lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.editFollowUpError)
+	}
+	repeatLabel := "Repeat:"
+	if v.editRepeatError != "" {
+		repeatLabel += " " + lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.editRepeatError)
+	}
+	blockedLabel := "Blocked by:"
+	if v.editBlockedError != "" {
+		blockedLabel += " " + lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.editBlockedError)
+	}
+	estimateLabel := "Estimate:"
+	if v.editEstimateError != "" {
+		estimateLabel += " " + lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.editEstimateError)
+	}
+
+	sections := []string{
 		s.Title.Render(formTitle),
 		"",
 		"Title:",
@@ -1219,53 +2636,76 @@ func (v *CardListView) renderEditForm() string {
 		"Description:",
 		descStyle.Render(v.editDesc.View()),
 		"",
-		"Tags:",
-		tagSelector,
-		"",
+	}
+	if v.editFieldEnabled(editFieldRef) {
+		sections = append(sections,
+			"Reference:",
+			refStyle.Width(inputWidth).Render(v.editRef.View()),
+			"",
+		)
+	}
+	if v.editFieldEnabled(editFieldWaiting) {
+		sections = append(sections,
+			"Waiting on:",
+			waitingStyle.Width(inputWidth).Render(v.editWaiting.View()),
+			"",
+		)
+	}
+	if v.editFieldEnabled(editFieldFollowUp) {
+		sections = append(sections,
+			followUpLabel,
+			followUpStyle.Width(inputWidth).Render(v.editFollowUp.View()),
+			"",
+		)
+	}
+	if v.editFieldEnabled(editFieldRepeat) {
+		sections = append(sections,
+			repeatLabel,
+			repeatStyle.Width(inputWidth).Render(v.editRepeat.View()),
+			"",
+		)
+	}
+	if v.editFieldEnabled(editFieldBlockedBy) {
+		sections = append(sections,
+			blockedLabel,
+			blockedStyle.Width(inputWidth).Render(v.editBlockedBy.View()),
+			"",
+		)
+	}
+	if v.editFieldEnabled(editFieldEstimate) {
+		sections = append(sections,
+			estimateLabel,
+			estimateStyle.Width(inputWidth).Render(v.editEstimate.View()),
+			"",
+		)
+	}
+	hint := "Tab: next • Ctrl+S: save • Esc: cancel"
+	if v.editFieldEnabled(editFieldTags) {
+		sections = append(sections,
+			"Tags:",
+			v.renderEditTagSelector(tagsStyle, inputWidth),
+			"",
+		)
+		hint = "Tab: next • ↑↓: select tag • /: filter tags • Space/↵: toggle • Ctrl+S: save • Esc: cancel"
+	}
+	sections = append(sections,
 		btnStyle.Render(" Save "),
 		"",
-		s.TitleMuted.Render("Tab: next • ↑↓: select tag • Space/↵: toggle • Ctrl+S: save • Esc: cancel"),
+		s.TitleMuted.Render(hint),
 	)
+	form := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		form,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(form, v.width, v.height)
 }
 
 func (v *CardListView) renderEditTagSelector(containerStyle lipgloss.Style, width int) string {
-	s := v.styles
-
-	if len(v.tags) == 0 {
-		return containerStyle.Width(width).Render(s.TitleMuted.Render("No tags available"))
-	}
-
-	var items []string
-	for i, tag := range v.tags {
-		isSelected := false
-		for _, t := range v.editTags {
-			if t == tag.Title {
-				isSelected = true
-				break
-			}
-		}
-
+	content := v.editTagPicker.render(v.styles, v.tags, 6, v.editFocusIdx == editFieldTags, func(tag models.Tag) string {
 		checkbox := "[ ]"
-		if isSelected {
+		if slices.Contains(v.editTags, tag.Title) {
 			checkbox = "[x]"
 		}
-
-		itemText := checkbox + " " + tag.Title
-
-		if v.editFocusIdx == 2 && i == v.editTagCursor {
-			items = append(items, s.ListSelected.Render(itemText))
-		} else {
-			items = append(items, s.ListItem.Render(itemText))
-		}
-	}
-
-	content := lipgloss.JoinVertical(lipgloss.Left, items...)
+		return checkbox + " " + tagLabel(tag.Title)
+	})
 	return containerStyle.Width(width).Render(content)
 }
 
@@ -1276,11 +2716,12 @@ func (v *CardListView) renderHelp() string {
 	}
 
 	return v.styles.Help.Render(
-		fmt.Sprintf("%s view • %s edit • %s new card • %s del card • %s new col • %s del col • %s search • %s filter • %s tags • %s←→ %s • %s back • %s quit",
+		fmt.Sprintf("%s view • %s edit • %s new card • %s del card • %s pin • %s new col • %s del col • %s search • %s filter • %s tags • %s←→ %s • %s back • %s quit",
 			v.styles.HelpKey.Render("↵"),
 			v.styles.HelpKey.Render("e"),
 			v.styles.HelpKey.Render("n"),
 			v.styles.HelpKey.Render("d"),
+			v.styles.HelpKey.Render("p"),
 			v.styles.HelpKey.Render("C"),
 			v.styles.HelpKey.Render("X"),
 			v.styles.HelpKey.Render("/"),
@@ -1294,6 +2735,69 @@ func (v *CardListView) renderHelp() string {
 	)
 }
 
+// Commands lists the card list's actions for the command palette: the detail
+// view's actions while a card is open, nothing while another prompt is up.
+func (v *CardListView) Commands() []Command {
+	switch {
+	case v.viewingCard && !v.commentInputFocused:
+		return []Command{
+			runeCommand("Edit card", "e"),
+			runeCommand("Assign tags", "t"),
+			runeCommand("Close or reopen card", "x"),
+			runeCommand("Delete card", "d"),
+			runeCommand("Add comment", "c"),
+			runeCommand("Snooze card", "z"),
+			runeCommand("Attach git branch", "g"),
+			runeCommand("Open reference", "o"),
+			runeCommand("Export card", "E"),
+			runeCommand("Show history", "H"),
+			runeCommand("Fold description", "D"),
+			runeCommand("Fold comments", "C"),
+		}
+	case v.showHelpPopup, v.confirmingDelete, v.confirmingDeleteColumn, v.confirmingClearClosed,
+		v.confirmingDiscard, v.creatingColumn, v.editing, v.snoozing, v.sharing, v.viewingCard,
+		v.assigningTags, v.retagging, v.moving, v.tagDropdownOpen, v.focus == FocusSearchInput:
+		return nil
+	}
+	return []Command{
+		runeCommand("New card", "n"),
+		runeCommand("Edit card", "e"),
+		runeCommand("Delete card", "d"),
+		runeCommand("Close card", "x"),
+		runeCommand("Close card and go to next", "."),
+		runeCommand("Undo last close", "u"),
+		runeCommand("Pin or unpin card", "p"),
+		runeCommand("Move card to column", "m"),
+		runeCommand("Assign tags", "t"),
+		runeCommand("Toggle quick tag", v.keys.QuickTag.Keys()[0]),
+		runeCommand("Center selected card", "z"),
+		runeCommand("Search", "/"),
+		runeCommand("Search comments too", "M"),
+		runeCommand("Filter by tag", "f"),
+		runeCommand("Retag cards", "R"),
+		runeCommand("Cycle sort field", "s"),
+		runeCommand("Flip sort direction", "S"),
+		runeCommand("Group by tag", "G"),
+		runeCommand("Expand or collapse completed", "H"),
+		runeCommand("Cycle closed cards: hide/only/dimmed", "c"),
+		runeCommand("Show snoozed cards", "Z"),
+		runeCommand("Show shelved cards", "Y"),
+		runeCommand("Archive or restore card", "a"),
+		runeCommand("Browse archive", "V"),
+		runeCommand("Show follow-ups due", "W"),
+		runeCommand("Create column", "C"),
+		runeCommand("Delete column", "X"),
+		runeCommand("Delete all closed cards", "D"),
+		runeCommand("Export visible cards", "E"),
+		runeCommand("Switch to previous board", "b"),
+		runeCommand("Activity heatmap", "A"),
+		runeCommand("Jump to card", ":"),
+		runeCommand("Keyboard shortcuts", "?"),
+		{Title: "Refresh", Key: tea.KeyMsg{Type: tea.KeyCtrlR}},
+		{Title: "Back to boards", Key: tea.KeyMsg{Type: tea.KeyEscape}},
+	}
+}
+
 func (v *CardListView) currentColumnName() string {
 	if v.currentColumn == 0 {
 		return "All"
@@ -1306,19 +2810,48 @@ func (v *CardListView) currentColumnName() string {
 
 func (v *CardListView) renderHelpPopup() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	helpItems := []string{
 		s.HelpKey.Render("↵") + "      view card",
 		s.HelpKey.Render("e") + "      edit card",
 		s.HelpKey.Render("n") + "      new card",
 		s.HelpKey.Render("d") + "      delete card",
+		s.HelpKey.Render("x") + "      close card",
+		s.HelpKey.Render(".") + "      close card and go to next",
+		s.HelpKey.Render("u") + "      undo last close",
+		s.HelpKey.Render("p") + "      pin/unpin card",
+		s.HelpKey.Render("m") + "      move card to column",
 		s.HelpKey.Render("C") + "      create column",
 		s.HelpKey.Render("X") + "      delete column",
-		s.HelpKey.Render("/") + "      search",
+		s.HelpKey.Render("D") + "      delete all closed (Done)",
+		s.HelpKey.Render("/") + "      search (tag:name, is:open, is:closed)",
+		s.HelpKey.Render("M") + "      search comments too",
 		s.HelpKey.Render("f") + "      filter by tag",
 		s.HelpKey.Render("t") + "      assign tags",
+		s.HelpKey.Render(v.keys.QuickTag.Help().Key) + "      " + v.keys.QuickTag.Help().Desc,
+		s.HelpKey.Render("R") + "      retag cards",
+		s.HelpKey.Render("Z") + "      show snoozed cards",
+		s.HelpKey.Render("Y") + "      show shelved cards",
+		s.HelpKey.Render("a") + "      archive/restore closed card",
+		s.HelpKey.Render("V") + "      browse archive",
+		s.HelpKey.Render("W") + "      show follow-ups due",
+		s.HelpKey.Render("H") + "      expand/collapse completed",
+		s.HelpKey.Render("c") + "      closed cards: hide/only/dimmed",
+		s.HelpKey.Render("G") + "      group by tag",
+		s.HelpKey.Render("-/+") + "    collapse group/expand all",
+		s.HelpKey.Render("{/}") + "    previous/next group",
+		s.HelpKey.Render("b") + "      switch to previous board",
+		s.HelpKey.Render("s") + "      cycle sort field",
+		s.HelpKey.Render("S") + "      flip sort direction",
 		s.HelpKey.Render("h/l") + "     switch column",
+		s.HelpKey.Render("pgup/dn") + " page up/down (ctrl+u/d)",
+		s.HelpKey.Render("z") + "      center selected card",
+		s.HelpKey.Render("A") + "      activity heatmap",
+		s.HelpKey.Render(":") + "      jump to card #",
+		s.HelpKey.Render("ctrl+o") + " quick capture to inbox",
+		s.HelpKey.Render("ctrl+p") + " command palette",
+		s.HelpKey.Render("ctrl+r") + " refresh",
+		s.HelpKey.Render("E") + "      export/copy visible cards",
 		s.HelpKey.Render("esc") + "    back",
 		s.HelpKey.Render("q") + "      quit",
 		"",
@@ -1329,64 +2862,44 @@ func (v *CardListView) renderHelpPopup() string {
 		append([]string{s.Title.Render("Keyboard Shortcuts"), ""}, helpItems...)...,
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		s.FilterBar.Render(content),
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
 }
 
 func (v *CardListView) renderTagAssignment() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
-	if len(v.cards) == 0 {
+	card, ok := v.selectedCard()
+	if !ok {
 		return ""
 	}
 
-	card := v.cards[v.cursor]
-
-	var items []string
-	for i, tag := range v.tags {
-		hasTag := false
-		for _, t := range card.Tags {
-			if t == tag.Title {
-				hasTag = true
-				break
-			}
-		}
-
-		itemStyle := s.ListItem
-		if i == v.assignTagCursor {
-			itemStyle = s.ListSelected
-		}
-
+	list := v.assignTagPicker.render(s, v.tags, v.height-12, true, func(tag models.Tag) string {
 		checkbox := "[ ]"
-		if hasTag {
+		if slices.Contains(card.Tags, tag.Title) {
 			checkbox = "[x]"
 		}
+		return checkbox + " " + tagLabel(tag.Title)
+	})
 
-		items = append(items, itemStyle.Render(checkbox+" "+tag.Title))
+	footer := s.TitleMuted.Render("/: filter • Enter/Space: toggle • Esc: done")
+	if v.confirmUntag != "" {
+		footer = lipgloss.NewStyle().Foreground(styles.Current.Warning).
+			Render(fmt.Sprintf("Remove %q from this card? ↵/y: yes • n: no", v.confirmUntag))
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		s.Title.Render("Assign Tags to: "+card.Title),
 		"",
-		lipgloss.JoinVertical(lipgloss.Left, items...),
+		list,
 		"",
-		s.TitleMuted.Render("Enter/Space: toggle • Esc: done"),
+		footer,
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		s.FilterBar.Render(content),
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
 }
 
 func (v *CardListView) renderDeleteConfirm() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Foreground(styles.Current.Error).Render("Delete Card?"),
@@ -1395,22 +2908,17 @@ func (v *CardListView) renderDeleteConfirm() string {
 		"",
 		"",
 		lipgloss.JoinHorizontal(lipgloss.Center,
-			s.ButtonPrimary.Render(" Y - Yes "),
+			s.ButtonPrimary.Render(" ↵ Y - Yes "),
 			"  ",
 			s.Button.Render(" N - No "),
 		),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(content, v.width, v.height)
 }
 
 func (v *CardListView) renderDeleteColumnConfirm() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Foreground(styles.Current.Error).Render("Delete Column?"),
@@ -1424,11 +2932,25 @@ func (v *CardListView) renderDeleteColumnConfirm() string {
 		),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
+	return styles.CenterViewMiddle(content, v.width, v.height)
+}
+
+func (v *CardListView) renderClearClosedConfirm() string {
+	s := v.styles
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		s.Title.Foreground(styles.Current.Error).Render("Delete All Closed Cards?"),
+		"",
+		s.TitleMuted.Render(fmt.Sprintf("%d closed cards on %s will be deleted", v.clearClosedCount, v.board.Name)),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center,
+			s.ButtonPrimary.Render(" Y - Yes "),
+			"  ",
+			s.Button.Render(" N - No "),
+		),
 	)
-	return styles.CenterView(centered, v.width, v.height)
+
+	return styles.CenterViewMiddle(content, v.width, v.height)
 }
 
 func (v *CardListView) renderCreateColumnForm() string {
@@ -1445,16 +2967,11 @@ func (v *CardListView) renderCreateColumnForm() string {
 		s.TitleMuted.Render("Enter/Ctrl+S: create • Esc: cancel"),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		form,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(form, v.width, v.height)
 }
 
 func (v *CardListView) renderDiscardConfirm() string {
 	s := v.styles
-	contentWidth := styles.ContentWidth(v.width)
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		s.Title.Foreground(styles.Current.Warning).Render("Discard unsaved changes?"),
@@ -1469,35 +2986,34 @@ func (v *CardListView) renderDiscardConfirm() string {
 		),
 	)
 
-	centered := lipgloss.Place(contentWidth, v.height,
-		lipgloss.Center, lipgloss.Center,
-		content,
-	)
-	return styles.CenterView(centered, v.width, v.height)
+	return styles.CenterViewMiddle(content, v.width, v.height)
 }
 
 func (v *CardListView) renderCardView() string {
-	if len(v.cards) == 0 || v.cursor >= len(v.cards) {
+	card, ok := v.selectedCard()
+	if !ok {
 		return ""
 	}
 
 	s := v.styles
-	card := v.cards[v.cursor]
 	maxContentWidth := styles.ContentWidth(v.width)
 	columnName := v.cardColumnName(card)
 
-	// Tags display
-	var tagsLine string
-	if len(card.Tags) > 0 {
-		tagsLine = strings.Join(card.Tags, " ")
-	} else {
-		tagsLine = "None"
+	tagsLine := renderTags(s, card.Tags)
+
+	refLine := v.settings.ExternalRef(card.Number)
+	if refLine == "" {
+		refLine = s.TitleMuted.Render("None")
 	}
+	blockedBy, blocks := v.dependencySummary(card)
 
 	// Description
 	descText := card.Description
-	if descText == "" {
+	switch {
+	case descText == "":
 		descText = s.TitleMuted.Render("No description")
+	case v.hideDetailDesc:
+		descText = s.TitleMuted.Render(fmt.Sprintf("(%d lines, hidden)", strings.Count(descText, "\n")+1))
 	}
 
 	titleStyle := s.Title.MarginBottom(1)
@@ -1507,31 +3023,52 @@ func (v *CardListView) renderCardView() string {
 	v.commentInput.SetWidth(clamp(textWidth, 20, 50))
 
 	// Comments section
-	userComments, latestSystemComment := splitCardComments(v.viewCardComments)
+	userComments, systemComments := splitCardComments(v.viewCardComments)
+
+	systemLabel := "Latest System Message"
+	if v.showCardHistory {
+		systemLabel = "History"
+	} else if len(systemComments) > 1 {
+		systemComments = systemComments[:1]
+	}
 
 	var systemContent string
-	if latestSystemComment != nil {
-		systemContent = lipgloss.NewStyle().Width(textWidth).Render(
-			fmt.Sprintf("%s: %s",
-				latestSystemComment.CreatedAt.Format("Jan 2, 2006 3:04 PM"),
-				latestSystemComment.Body,
-			),
-		)
-	} else {
+	if len(systemComments) == 0 {
 		systemContent = s.TitleMuted.Render("No system messages")
+	} else {
+		lines := make([]string, len(systemComments))
+		for i, comment := range systemComments {
+			lines[i] = lipgloss.NewStyle().Width(textWidth).Render(
+				fmt.Sprintf("%s: %s", comment.CreatedAt.Format("Jan 2, 2006 3:04 PM"), comment.Body),
+			)
+		}
+		systemContent = lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
 	var commentsContent string
 	if len(userComments) == 0 {
 		commentsContent = s.TitleMuted.Render("No comments yet")
+	} else if v.hideDetailComments {
+		commentsContent = s.TitleMuted.Render(fmt.Sprintf("(%d comments, hidden)", len(userComments)))
 	} else {
+		// Each comment sits behind a left rule; the rule and its padding take two
+		// columns, so the header and body wrap two narrower than textWidth.
+		blockStyle := lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(styles.Current.Border).
+			PaddingLeft(1)
+		innerWidth := textWidth - 2
+
 		var commentLines []string
 		for _, comment := range userComments {
-			timestamp := comment.CreatedAt.Format("Jan 2, 2006 3:04 PM")
-			commentLine := lipgloss.JoinVertical(lipgloss.Left,
-				labelStyle.Render(timestamp),
-				lipgloss.NewStyle().Width(textWidth).Render(comment.Body),
-			)
+			header := comment.CreatedAt.Format("Jan 2, 2006 3:04 PM")
+			if comment.Author != "" {
+				header = comment.Author + " · " + header
+			}
+			commentLine := blockStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+				labelStyle.Width(innerWidth).Render(header),
+				lipgloss.NewStyle().Width(innerWidth).Render(comment.Body),
+			))
 			commentLines = append(commentLines, commentLine)
 		}
 		commentsContent = lipgloss.JoinVertical(lipgloss.Left, appendInterleaved(commentLines, "")...)
@@ -1552,17 +3089,25 @@ func (v *CardListView) renderCardView() string {
 		)
 	} else {
 		helpText = s.Help.Render(
-			fmt.Sprintf("%s edit • %s tags • %s close • %s comment • %s back",
+			fmt.Sprintf("%s edit • %s tags • %s close/reopen • %s delete • %s comment • %s snooze • %s shelve • %s git • %s open ref • %s history • %s/%s fold desc/comments • %s back",
 				s.HelpKey.Render("e"),
 				s.HelpKey.Render("t"),
+				s.HelpKey.Render("x"),
 				s.HelpKey.Render("d"),
 				s.HelpKey.Render("c"),
+				s.HelpKey.Render("z"),
+				s.HelpKey.Render("y"),
+				s.HelpKey.Render("g"),
+				s.HelpKey.Render("o"),
+				s.HelpKey.Render("H"),
+				s.HelpKey.Render("D"),
+				s.HelpKey.Render("C"),
 				s.HelpKey.Render("esc"),
 			),
 		)
 	}
 
-	content := lipgloss.JoinVertical(lipgloss.Left,
+	sections := []string{
 		titleStyle.Render(fmt.Sprintf("#%d %s", card.Number, card.Title)),
 		"",
 		labelStyle.Render("Column"),
@@ -1571,10 +3116,28 @@ func (v *CardListView) renderCardView() string {
 		labelStyle.Render("Tags"),
 		tagsLine,
 		"",
+		labelStyle.Render("Reference"),
+		refLine,
+		"",
+		labelStyle.Render("Waiting On"),
+		v.waitingSummary(card),
+		"",
+		labelStyle.Render("Repeats"),
+		v.repeatSummary(card),
+		"",
+		labelStyle.Render("Estimate"),
+		v.estimateSummary(card),
+		"",
+		labelStyle.Render("Blocked By"),
+		blockedBy,
+		"",
+		labelStyle.Render("Blocks"),
+		blocks,
+		"",
 		labelStyle.Render("Description"),
 		lipgloss.NewStyle().Width(textWidth).Render(descText),
 		"",
-		labelStyle.Render("Latest System Message"),
+		labelStyle.Render(systemLabel),
 		systemContent,
 		"",
 		commentInputStyle.Render(v.commentInput.View()),
@@ -1582,8 +3145,13 @@ func (v *CardListView) renderCardView() string {
 		labelStyle.Render("Comments"),
 		commentsContent,
 		"",
-		helpText,
-	)
+	}
+	if v.status != "" {
+		sections = append(sections, s.StatusBar.Render(v.status))
+	}
+	sections = append(sections, helpText)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	padded := lipgloss.NewStyle().Padding(1, 2).Render(content)
 	return styles.CenterView(padded, v.width, v.height)
@@ -1603,26 +3171,24 @@ func (v *CardListView) cardColumnName(card models.Card) string {
 	return "Unassigned"
 }
 
-func splitCardComments(comments []models.Comment) ([]models.Comment, *models.Comment) {
-	userComments := make([]models.Comment, 0, len(comments))
-	var latestSystemComment *models.Comment
-
+// splitCardComments separates user comments from the system messages fizzy
+// records for card events, returning both newest first.
+func splitCardComments(comments []models.Comment) (userComments, systemComments []models.Comment) {
 	for _, comment := range comments {
 		if isSystemComment(comment) {
-			if latestSystemComment == nil || comment.CreatedAt.After(latestSystemComment.CreatedAt) {
-				commentCopy := comment
-				latestSystemComment = &commentCopy
-			}
-			continue
+			systemComments = append(systemComments, comment)
+		} else {
+			userComments = append(userComments, comment)
 		}
-		userComments = append(userComments, comment)
 	}
 
-	sort.Slice(userComments, func(i, j int) bool {
-		return userComments[i].CreatedAt.After(userComments[j].CreatedAt)
-	})
+	for _, list := range [][]models.Comment{userComments, systemComments} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		})
+	}
 
-	return userComments, latestSystemComment
+	return userComments, systemComments
 }
 
 func isSystemComment(comment models.Comment) bool {
@@ -1686,10 +3252,130 @@ func (v *CardListView) currentRealColumn() *models.Column {
 	return &col
 }
 
+// quickTagSettingKey names the tag toggled by the quick tag key.
+const quickTagSettingKey = "quick_tag"
+
+// wrapNavigationSettingKey enables wrapping from the last list item to the first and back.
+const wrapNavigationSettingKey = "wrap_navigation"
+
+// confirmUntagSettingKey lists tags whose removal in the tag picker must be confirmed.
+const confirmUntagSettingKey = "confirm_untag"
+
+// bellOnCompleteSettingKey rings the terminal bell whenever a card is closed, if "true".
+const bellOnCompleteSettingKey = "bell_on_complete"
+
+// undoCloseWindow is how long after closing a card u can still reopen it.
+const undoCloseWindow = time.Minute
+
+// showDescriptionsSettingKey shows a one-line description snippet on each card row when "true".
+const showDescriptionsSettingKey = "show_descriptions"
+
+// compactRowsSettingKey shows card rows as a single title line when "true",
+// with the tag line only on the selected row.
+const compactRowsSettingKey = "compact_rows"
+
+// hideCardNumbersSettingKey drops the muted #number prefix from card rows when "true".
+const hideCardNumbersSettingKey = "hide_card_numbers"
+
+// hideDescriptionGlyphSettingKey drops the 📝 marker on rows of cards with a description when "true".
+const hideDescriptionGlyphSettingKey = "hide_description_glyph"
+
+// PreviousBoardSettingKey holds the board opened before the current one.
+const PreviousBoardSettingKey = "previous_board_id"
+
+// hiddenEditFieldsSettingKey holds a comma-separated list of optional edit form
+// fields to skip: ref, waiting, follow_up, repeat, blocked_by, estimate and tags.
+const hiddenEditFieldsSettingKey = "hidden_edit_fields"
+
 func lastColumnSettingKey(boardID string) string {
 	return "last_column_id:" + boardID
 }
 
+func sortFieldSettingKey(boardID string) string {
+	return "sort_field:" + boardID
+}
+
+func sortDirSettingKey(boardID string) string {
+	return "sort_dir:" + boardID
+}
+
+// untaggedFilter is the selectedTag value that lists only cards without tags.
+// A NUL byte can't appear in a tag title, so it never clashes with a real tag.
+const untaggedFilter = "\x00untagged"
+
+func tagFilterSettingKey(boardID string) string {
+	return "tag_filter:" + boardID
+}
+
+func pinnedCardsSettingKey(boardID string) string {
+	return "pinned_cards:" + boardID
+}
+
+// loadPinnedCards reads the comma-separated list of pinned card numbers for a board.
+func loadPinnedCards(settings *fizzy.Settings, boardID string) map[int]bool {
+	pinned := make(map[int]bool)
+	if settings == nil {
+		return pinned
+	}
+	for _, field := range strings.Split(settings.Get(pinnedCardsSettingKey(boardID)), ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+			pinned[n] = true
+		}
+	}
+	return pinned
+}
+
+func (v *CardListView) savePinnedCards() {
+	if v.settings == nil {
+		return
+	}
+	numbers := make([]int, 0, len(v.pinned))
+	for n := range v.pinned {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	fields := make([]string, len(numbers))
+	for i, n := range numbers {
+		fields[i] = strconv.Itoa(n)
+	}
+	_ = v.settings.Set(pinnedCardsSettingKey(v.board.ID), strings.Join(fields, ","))
+}
+
+// togglePinned pins or unpins a card and keeps the cursor on it after re-sorting.
+func (v *CardListView) togglePinned(number int) {
+	if v.pinned[number] {
+		delete(v.pinned, number)
+	} else {
+		v.pinned[number] = true
+	}
+	v.savePinnedCards()
+	v.sortCards()
+	v.selectCard(number)
+}
+
+func (v *CardListView) sortCards() {
+	sortCards(v.cards, v.sort, v.pinned, v.loadOrder)
+}
+
+// setSort applies a new sort, persists it for the board and keeps the cursor on the selected card.
+func (v *CardListView) setSort(cs cardSort) {
+	selected, _ := v.selectedCard()
+
+	v.sort = cs
+	v.sortCards()
+	if v.settings != nil {
+		dir := "asc"
+		if cs.desc {
+			dir = "desc"
+		}
+		_ = v.settings.Set(sortFieldSettingKey(v.board.ID), cs.field.String())
+		_ = v.settings.Set(sortDirSettingKey(v.board.ID), dir)
+	}
+
+	v.selectCard(selected.Number)
+}
+
 func appendInterleaved(items []string, separator string) []string {
 	if len(items) < 2 {
 		return items
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+const multiParagraph = "First paragraph\twith a tab   and  runs of spaces.\n\n" +
+	"Second paragraph, which goes on for long enough that it can never fit in a single row of the list.\n\n" +
+	"Third."
+
+func TestTruncateLine(t *testing.T) {
+	for _, width := range []int{10, 25, 60} {
+		got := truncateLine(multiParagraph, width)
+		if strings.ContainsAny(got, "\n\t") {
+			t.Errorf("truncateLine(width %d) = %q, want a single line without tabs", width, got)
+		}
+		if strings.Contains(got, "  ") {
+			t.Errorf("truncateLine(width %d) = %q, want whitespace collapsed", width, got)
+		}
+		if !strings.HasSuffix(got, "…") {
+			t.Errorf("truncateLine(width %d) = %q, want an ellipsis", width, got)
+		}
+		if w := lipgloss.Width(got); w > width {
+			t.Errorf("truncateLine(width %d) is %d cells wide", width, w)
+		}
+	}
+
+	if got, want := truncateLine("short\n\n  text", 20), "short text"; got != want {
+		t.Errorf("truncateLine of fitting text = %q, want %q", got, want)
+	}
+	if got := truncateLine(multiParagraph, 0); got != "" {
+		t.Errorf("truncateLine(width 0) = %q, want empty", got)
+	}
+}
+
+func TestRenderCardItemDescriptionSnippet(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	settings, err := fizzy.NewSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := settings.Set(showDescriptionsSettingKey, "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewCardListView(nil, settings, models.Board{ID: "b1", Name: "Board"})
+	v.width = 80
+	card := models.Card{Number: 7, Title: "Write docs", Tags: []string{"docs"}, Description: multiParagraph}
+
+	for _, selected := range []bool{false, true} {
+		row := strings.TrimSuffix(v.renderCardItem(card, selected), "\n")
+		lines := strings.Split(row, "\n")
+		if len(lines) != 2 {
+			t.Fatalf("row has %d lines, want title and tag line:\n%s", len(lines), row)
+		}
+
+		snippet := lines[1]
+		if !strings.Contains(snippet, "First paragraph with a tab and runs of spaces. Second") {
+			t.Errorf("snippet %q doesn't collapse the description's whitespace", snippet)
+		}
+		if !strings.Contains(snippet, "…") {
+			t.Errorf("snippet %q isn't cut with an ellipsis", snippet)
+		}
+		if strings.Contains(snippet, "Third.") {
+			t.Errorf("snippet %q runs past the row", snippet)
+		}
+		for _, line := range lines {
+			if w := lipgloss.Width(line); w > styles.ContentWidth(v.width) {
+				t.Errorf("line %q is %d cells wide, wider than the %d cell content", line, w, styles.ContentWidth(v.width))
+			}
+		}
+	}
+}
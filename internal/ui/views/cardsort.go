@@ -0,0 +1,98 @@
+package views
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+type sortField int
+
+const (
+	sortByDefault sortField = iota // order returned by fizzy
+	sortByNumber
+	sortByTitle
+	sortByCreated
+)
+
+var sortFieldNames = []string{"default", "number", "title", "created"}
+
+func (f sortField) String() string {
+	if int(f) < len(sortFieldNames) {
+		return sortFieldNames[f]
+	}
+	return sortFieldNames[sortByDefault]
+}
+
+func parseSortField(s string) sortField {
+	for i, name := range sortFieldNames {
+		if name == s {
+			return sortField(i)
+		}
+	}
+	return sortByDefault
+}
+
+// cardSort is a sort field paired with a direction
+type cardSort struct {
+	field sortField
+	desc  bool
+}
+
+// Arrow returns the direction indicator shown in the header
+func (cs cardSort) Arrow() string {
+	if cs.desc {
+		return "↓"
+	}
+	return "↑"
+}
+
+func (cs cardSort) Label() string {
+	return cs.field.String() + " " + cs.Arrow()
+}
+
+// less compares two cards by the sort field; order is each card's original
+// position and breaks ties so the sort is deterministic.
+func (cs cardSort) less(a, b models.Card, order map[int]int) bool {
+	var cmp int
+	switch cs.field {
+	case sortByNumber:
+		cmp = a.Number - b.Number
+	case sortByTitle:
+		cmp = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case sortByCreated:
+		cmp = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if cmp == 0 {
+		cmp = order[a.Number] - order[b.Number]
+	}
+	if cs.desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// sortCards orders cards by the given sort, always placing pinned cards first
+func sortCards(cards []models.Card, cs cardSort, pinned map[int]bool, order map[int]int) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		if pinned[a.Number] != pinned[b.Number] {
+			return pinned[a.Number]
+		}
+		return cs.less(a, b, order)
+	})
+}
+
+// keepOrder reorders cards to match the positions in prev, so a reload doesn't move
+// cards that were already listed. Cards not in prev keep their order after the rest.
+func keepOrder(cards []models.Card, prev map[int]int) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, aok := prev[cards[i].Number]
+		b, bok := prev[cards[j].Number]
+		if aok != bok {
+			return aok
+		}
+		return aok && a < b
+	})
+}
@@ -0,0 +1,103 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tgienger/stm/internal/models"
+)
+
+// completedSectionSettingKey, when "true", lists closed cards in the All column
+// under a collapsible "Completed" header instead of leaving them to the Done column.
+const completedSectionSettingKey = "completed_section"
+
+// completedSectionActive reports whether the All column is showing the Completed section.
+func (v *CardListView) completedSectionActive() bool {
+	return v.closedDisplayActive() == closedHidden && v.currentColumn == 0 &&
+		v.settings.Bool(completedSectionSettingKey)
+}
+
+// partitionClosed splits cards into open and closed, keeping their order.
+func partitionClosed(cards []models.Card) (open, closed []models.Card) {
+	for _, c := range cards {
+		if c.ColumnID == "done" {
+			closed = append(closed, c)
+		} else {
+			open = append(open, c)
+		}
+	}
+	return open, closed
+}
+
+func (v *CardListView) toggleCompletedSection() {
+	if !v.completedSectionActive() {
+		v.status = "Set completed_section to true to list closed cards in All"
+		return
+	}
+	v.completedExpanded = !v.completedExpanded
+	v.clampVisibleState()
+}
+
+func (v *CardListView) renderCompletedHeader(count int) string {
+	arrow := "▸"
+	if v.completedExpanded {
+		arrow = "▾"
+	}
+	return v.styles.TitleMuted.Render(fmt.Sprintf("%s Completed (%d)", arrow, count))
+}
+
+// closedDisplay is how the All column treats closed cards, cycled with c
+type closedDisplay int
+
+const (
+	closedHidden closedDisplay = iota // leave closed cards to the Done column
+	closedOnly                        // list only closed cards
+	closedDimmed                      // list everything, closed cards dimmed at the bottom
+)
+
+var closedDisplayNames = []string{"hide", "only", "dimmed"}
+
+func (d closedDisplay) String() string {
+	return closedDisplayNames[d]
+}
+
+func parseClosedDisplay(s string) closedDisplay {
+	for i, name := range closedDisplayNames {
+		if name == s {
+			return closedDisplay(i)
+		}
+	}
+	return closedHidden
+}
+
+// closedDisplaySettingKey remembers the closed card mode: hide, only or dimmed.
+const closedDisplaySettingKey = "closed_display"
+
+// closedDisplayActive returns the closed card mode in effect, which is always
+// closedHidden outside the All column.
+func (v *CardListView) closedDisplayActive() closedDisplay {
+	if v.currentColumn != 0 {
+		return closedHidden
+	}
+	return v.closedDisplay
+}
+
+func (v *CardListView) cycleClosedDisplay() tea.Cmd {
+	if v.currentColumn != 0 {
+		v.status = "Closed cards can only be shown in All"
+		return nil
+	}
+	v.closedDisplay = (v.closedDisplay + 1) % closedDisplay(len(closedDisplayNames))
+	_ = v.settings.Set(closedDisplaySettingKey, v.closedDisplay.String())
+	switch v.closedDisplay {
+	case closedOnly:
+		v.status = "Showing closed cards only"
+	case closedDimmed:
+		v.status = "Showing all cards, closed ones dimmed"
+	default:
+		v.status = "Hiding closed cards"
+	}
+	v.cursor = 0
+	v.scrollY = 0
+	return v.loadCards
+}
@@ -0,0 +1,114 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// hideBlockedSettingKey, when "true", leaves cards with open blockers out of the list.
+const hideBlockedSettingKey = "hide_blocked"
+
+// parseBlockers reads a blocked-by field such as "#12, 15" into card numbers
+func parseBlockers(text string) ([]int, error) {
+	var numbers []int
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(strings.TrimPrefix(field, "#"))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%q is not a card number", field)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+func formatBlockers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = fmt.Sprintf("#%d", n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// checkBlockers reports an error if card blocking on blockers would make a
+// card wait on itself, directly or through other cards' blockers.
+func (v *CardListView) checkBlockers(card int, blockers []int) error {
+	for _, b := range blockers {
+		if b == card {
+			return fmt.Errorf("a card can't block itself")
+		}
+		if v.waitsOn(b, card, make(map[int]bool)) {
+			return fmt.Errorf("#%d already waits on #%d", b, card)
+		}
+	}
+	return nil
+}
+
+// waitsOn reports whether from is blocked by target, directly or transitively
+func (v *CardListView) waitsOn(from, target int, seen map[int]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, b := range v.settings.BlockedBy(from) {
+		if b == target || v.waitsOn(b, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// openBlockers returns the card's blockers that are still open on this board.
+// Blockers on other boards aren't tracked and never hold a card up.
+func (v *CardListView) openBlockers(card models.Card) []int {
+	var open []int
+	for _, b := range v.settings.BlockedBy(card.Number) {
+		if v.openNumbers[b] {
+			open = append(open, b)
+		}
+	}
+	return open
+}
+
+func (v *CardListView) isBlocked(card models.Card) bool {
+	return len(v.openBlockers(card)) > 0
+}
+
+// blockedBadge marks a row whose card is waiting on open cards
+func (v *CardListView) blockedBadge(card models.Card) string {
+	open := v.openBlockers(card)
+	if len(open) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(styles.Current.Warning).Render("⛔ " + formatBlockers(open))
+}
+
+// dependencySummary lists the card's blockers, muting those that no longer hold
+// it up, and the cards waiting on it, for the detail view.
+func (v *CardListView) dependencySummary(card models.Card) (blockedBy, blocks string) {
+	s := v.styles
+	blockers := v.settings.BlockedBy(card.Number)
+	if len(blockers) == 0 {
+		blockedBy = s.TitleMuted.Render("Nothing")
+	} else {
+		parts := make([]string, len(blockers))
+		for i, b := range blockers {
+			parts[i] = fmt.Sprintf("#%d", b)
+			if !v.openNumbers[b] {
+				parts[i] = s.TitleMuted.Render(parts[i])
+			}
+		}
+		blockedBy = strings.Join(parts, ", ")
+	}
+
+	if waiting := v.settings.Blocks(card.Number); len(waiting) > 0 {
+		blocks = formatBlockers(waiting)
+	} else {
+		blocks = s.TitleMuted.Render("Nothing")
+	}
+	return blockedBy, blocks
+}
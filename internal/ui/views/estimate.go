@@ -0,0 +1,53 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+// parseEstimate reads an effort estimate such as "45", "30m", "2h" or "1h30m"
+// into minutes. A bare number is minutes; empty text means no estimate.
+func parseEstimate(text string) (int, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("%q is not a positive estimate", text)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil || d < time.Minute {
+		return 0, fmt.Errorf("%q is not an estimate like 30m or 2h", text)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// formatEstimate writes minutes the way parseEstimate reads them, or "" for none.
+func formatEstimate(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return ""
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	}
+}
+
+// estimateSummary describes a card's estimated effort for the detail view.
+// stm doesn't track time spent, so there is nothing to compare it against.
+func (v *CardListView) estimateSummary(card models.Card) string {
+	if minutes := v.settings.Estimate(card.Number); minutes > 0 {
+		return "est " + formatEstimate(minutes)
+	}
+	return v.styles.TitleMuted.Render("None")
+}
@@ -0,0 +1,193 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+// untaggedGroup heads cards with no tags in group-by-tag mode.
+const untaggedGroup = "Untagged"
+
+// cardGroup is one header in group-by-tag mode and the cards under it.
+type cardGroup struct {
+	name  string
+	cards []models.Card
+}
+
+// groupByTag lists each card once under every tag it carries, with tags in
+// alphabetical order and untagged cards last.
+func groupByTag(cards []models.Card) []cardGroup {
+	byTag := make(map[string][]models.Card)
+	var untagged []models.Card
+	for _, c := range cards {
+		if len(c.Tags) == 0 {
+			untagged = append(untagged, c)
+			continue
+		}
+		for _, t := range c.Tags {
+			byTag[t] = append(byTag[t], c)
+		}
+	}
+
+	names := make([]string, 0, len(byTag))
+	for name := range byTag {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	groups := make([]cardGroup, 0, len(names)+1)
+	for _, name := range names {
+		groups = append(groups, cardGroup{name: name, cards: byTag[name]})
+	}
+	if len(untagged) > 0 {
+		groups = append(groups, cardGroup{name: untaggedGroup, cards: untagged})
+	}
+	return groups
+}
+
+// flattenGroups returns the cards of expanded groups in display order.
+func (v *CardListView) flattenGroups(groups []cardGroup) []models.Card {
+	var cards []models.Card
+	for _, g := range groups {
+		if !v.collapsedGroups[g.name] {
+			cards = append(cards, g.cards...)
+		}
+	}
+	return cards
+}
+
+// currentGroup returns the group the cursor is in.
+func (v *CardListView) currentGroup() (string, bool) {
+	idx := 0
+	for _, g := range groupByTag(v.ungroupedCards()) {
+		if v.collapsedGroups[g.name] {
+			continue
+		}
+		if v.cursor < idx+len(g.cards) {
+			return g.name, true
+		}
+		idx += len(g.cards)
+	}
+	return "", false
+}
+
+// toggleCurrentGroup collapses the cursor's group, or expands every group if it can't
+// tell which one (for example when all are collapsed).
+func (v *CardListView) toggleCurrentGroup() {
+	name, ok := v.currentGroup()
+	if !ok {
+		v.collapsedGroups = make(map[string]bool)
+		v.clampVisibleState()
+		return
+	}
+	v.collapsedGroups[name] = true
+	v.clampVisibleState()
+}
+
+// jumpGroup moves the cursor to the first card of the next (dir > 0) or previous group.
+func (v *CardListView) jumpGroup(dir int) {
+	var starts []int
+	idx := 0
+	for _, g := range groupByTag(v.ungroupedCards()) {
+		if v.collapsedGroups[g.name] {
+			continue
+		}
+		starts = append(starts, idx)
+		idx += len(g.cards)
+	}
+	if len(starts) == 0 {
+		return
+	}
+
+	target := starts[0]
+	if dir > 0 {
+		target = starts[len(starts)-1]
+		for _, start := range starts {
+			if start > v.cursor {
+				target = start
+				break
+			}
+		}
+	} else {
+		for _, start := range starts {
+			if start < v.cursor {
+				target = start
+			}
+		}
+	}
+	v.cursor = target
+	v.ensureVisible()
+}
+
+func (v *CardListView) renderGroupHeader(g cardGroup) string {
+	arrow := "▾"
+	if v.collapsedGroups[g.name] {
+		arrow = "▸"
+	}
+	return v.styles.Title.Render(fmt.Sprintf("%s %s (%d)", arrow, g.name, len(g.cards)))
+}
+
+// renderGroupedList renders the visible window of the list with a header above
+// each group; collapsed groups show only their header.
+func (v *CardListView) renderGroupedList() []string {
+	items, _ := v.groupedWindow(v.scrollY, true)
+	return items
+}
+
+// groupedCardsFitting is how many cards fit in the list area from the card at
+// index from, once the group headers among them take their rows.
+func (v *CardListView) groupedCardsFitting(from int) int {
+	_, cards := v.groupedWindow(from, false)
+	return max(cards, 1)
+}
+
+// groupedWindow lays out the grouped list from the card at index from until
+// the list area is full, counting each group header as a row like a card. It
+// returns the rendered rows when render is set, and how many cards fit.
+func (v *CardListView) groupedWindow(from int, render bool) ([]string, int) {
+	var items []string
+	rows := v.visibleItems()
+	cards := 0
+	idx := 0
+	for _, g := range groupByTag(v.ungroupedCards()) {
+		if rows == 0 {
+			break
+		}
+		if v.collapsedGroups[g.name] {
+			if idx >= from {
+				if render {
+					items = append(items, v.renderGroupHeader(g))
+				}
+				rows--
+			}
+			continue
+		}
+		if idx+len(g.cards) <= from {
+			idx += len(g.cards)
+			continue
+		}
+		if render {
+			items = append(items, v.renderGroupHeader(g))
+		}
+		rows--
+		for _, card := range g.cards {
+			if rows == 0 {
+				break
+			}
+			if idx >= from {
+				if render {
+					items = append(items, v.renderCardItem(card, idx == v.cursor && v.focus == FocusCardList))
+				}
+				rows--
+				cards++
+			}
+			idx++
+		}
+	}
+	return items, cards
+}
@@ -0,0 +1,128 @@
+package views
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// inboxNudgeSettingKey, when "true", offers at startup to triage the inbox
+// board's untagged open cards.
+const inboxNudgeSettingKey = "inbox_nudge"
+
+// InboxNudge is sent at startup when the inbox board has untagged open cards
+type InboxNudge struct {
+	Board models.Board
+	Count int
+}
+
+// TriageInbox asks the app to open the inbox board filtered to untagged cards
+type TriageInbox struct {
+	Board models.Board
+}
+
+// CancelInboxNudge dismisses the nudge until the next start
+type CancelInboxNudge struct{}
+
+// CheckInbox counts the untagged open cards on the inbox board, returning an
+// InboxNudge if there are any. It does nothing unless inbox_nudge is on.
+func CheckInbox(f *fizzy.Fizzy, settings *fizzy.Settings) tea.Cmd {
+	if !settings.Bool(inboxNudgeSettingKey) {
+		return nil
+	}
+	name := inboxBoardName(settings)
+	return func() tea.Msg {
+		boards, err := f.ListBoards()
+		if err != nil {
+			slog.Warn("inbox nudge: listing boards", "err", err)
+			return nil
+		}
+		for _, board := range boards {
+			if !strings.EqualFold(board.Name, name) {
+				continue
+			}
+			cards, err := f.ListCards(board.ID)
+			if err != nil {
+				slog.Warn("inbox nudge: listing cards", "board", board.Name, "err", err)
+				return nil
+			}
+			count := 0
+			for _, c := range cards {
+				if len(c.Tags) == 0 {
+					count++
+				}
+			}
+			if count == 0 {
+				return nil
+			}
+			return InboxNudge{Board: board, Count: count}
+		}
+		return nil
+	}
+}
+
+// InboxNudgeView asks whether to triage the inbox now
+type InboxNudgeView struct {
+	styles *styles.Styles
+	keys   keys.KeyMap
+	nudge  InboxNudge
+
+	width  int
+	height int
+}
+
+func NewInboxNudgeView(nudge InboxNudge) *InboxNudgeView {
+	return &InboxNudgeView{
+		styles: styles.NewStyles(),
+		keys:   keys.DefaultKeyMap(),
+		nudge:  nudge,
+	}
+}
+
+func (v *InboxNudgeView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *InboxNudgeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, v.keys.Enter), msg.String() == "y":
+			board := v.nudge.Board
+			return v, func() tea.Msg { return TriageInbox{Board: board} }
+		case key.Matches(msg, v.keys.Back), msg.String() == "n":
+			return v, func() tea.Msg { return CancelInboxNudge{} }
+		}
+	}
+	return v, nil
+}
+
+func (v *InboxNudgeView) View() string {
+	s := v.styles
+
+	noun := "cards"
+	if v.nudge.Count == 1 {
+		noun = "card"
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Inbox"),
+		"",
+		fmt.Sprintf("%d untagged %s on %s", v.nudge.Count, noun, v.nudge.Board.Name),
+		"",
+		s.TitleMuted.Render("↵: triage now • Esc: later"),
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
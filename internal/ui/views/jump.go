@@ -0,0 +1,137 @@
+package views
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// JumpView is a small prompt for jumping straight to a card by its number
+type JumpView struct {
+	fizzy  *fizzy.Fizzy
+	styles *styles.Styles
+	keys   keys.KeyMap
+	input  textinput.Model
+
+	width     int
+	height    int
+	searching bool
+	errMsg    string
+}
+
+func NewJumpView(f *fizzy.Fizzy) *JumpView {
+	input := textinput.New()
+	input.Placeholder = "Card number"
+	input.CharLimit = 10
+	input.Focus()
+
+	return &JumpView{
+		fizzy:  f,
+		styles: styles.NewStyles(),
+		keys:   keys.DefaultKeyMap(),
+		input:  input,
+	}
+}
+
+// StartJump asks the app to open the jump prompt
+type StartJump struct{}
+
+// CancelJump closes the jump prompt without navigating
+type CancelJump struct{}
+
+// JumpToCard is sent once a card has been located
+type JumpToCard struct {
+	Board models.Board
+	Card  models.Card
+}
+
+type jumpFailedMsg struct {
+	err error
+}
+
+func (v *JumpView) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (v *JumpView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case jumpFailedMsg:
+		v.searching = false
+		if errors.Is(msg.err, fizzy.ErrCardNotFound) {
+			v.errMsg = "Card not found"
+		} else {
+			v.errMsg = "Lookup failed"
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.searching {
+			return v, nil
+		}
+		switch {
+		case key.Matches(msg, v.keys.Back):
+			return v, func() tea.Msg { return CancelJump{} }
+		case key.Matches(msg, v.keys.Enter):
+			number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v.input.Value()), "#"))
+			if err != nil || number <= 0 {
+				v.errMsg = "Enter a card number"
+				return v, nil
+			}
+			v.searching = true
+			v.errMsg = ""
+			return v, v.findCard(number)
+		}
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *JumpView) findCard(number int) tea.Cmd {
+	return func() tea.Msg {
+		board, card, err := v.fizzy.FindCard(number)
+		if err != nil {
+			return jumpFailedMsg{err: err}
+		}
+		return JumpToCard{Board: *board, Card: *card}
+	}
+}
+
+func (v *JumpView) View() string {
+	s := v.styles
+	contentWidth := styles.ContentWidth(v.width)
+	inputWidth := clamp(contentWidth-6, 20, 30)
+
+	status := s.TitleMuted.Render("↵: go • Esc: cancel")
+	switch {
+	case v.searching:
+		status = s.TitleMuted.Render("Searching...")
+	case v.errMsg != "":
+		status = lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.errMsg)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Jump to Card"),
+		"",
+		s.InputFocused.Width(inputWidth).Render(v.input.View()),
+		"",
+		status,
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
@@ -0,0 +1,116 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// moveTargets lists the columns a card can be moved into; pseudo columns such
+// as Done are reached by closing the card instead.
+func (v *CardListView) moveTargets() []models.Column {
+	var cols []models.Column
+	for _, c := range v.columns {
+		if !c.Pseudo {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// startMove opens the column picker for card, starting on the column after its current one.
+func (v *CardListView) startMove(card models.Card) {
+	targets := v.moveTargets()
+	if len(targets) == 0 {
+		v.status = "No columns to move to"
+		return
+	}
+	v.moving = true
+	v.moveCard = card
+	v.moveColumnCursor = 0
+	for i, c := range targets {
+		if c.ID == card.ColumnID {
+			v.moveColumnCursor = (i + 1) % len(targets)
+		}
+	}
+}
+
+func (v *CardListView) updateMoving(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	targets := v.moveTargets()
+
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.moving = false
+		return v, nil
+
+	case key.Matches(msg, v.keys.Up):
+		if v.moveColumnCursor > 0 {
+			v.moveColumnCursor--
+		}
+		return v, nil
+
+	case key.Matches(msg, v.keys.Down):
+		if v.moveColumnCursor < len(targets)-1 {
+			v.moveColumnCursor++
+		}
+		return v, nil
+
+	case key.Matches(msg, v.keys.Enter):
+		if v.moveColumnCursor >= len(targets) {
+			return v, nil
+		}
+		v.moving = false
+		return v, v.moveCardTo(v.moveCard, targets[v.moveColumnCursor])
+	}
+
+	return v, nil
+}
+
+func (v *CardListView) moveCardTo(card models.Card, col models.Column) tea.Cmd {
+	if card.ColumnID == col.ID {
+		v.status = fmt.Sprintf("#%d is already in %s", card.Number, col.Name)
+		return nil
+	}
+	if err := v.fizzy.MoveCardToColumn(card.Number, col.ID); err != nil {
+		v.status = fmt.Sprintf("Failed to move #%d", card.Number)
+		return nil
+	}
+	// In the All column the card stays listed, so keep the cursor on it;
+	// otherwise it drops out and the cursor lands on the card that took its place.
+	if v.currentColumn == 0 {
+		v.pendingSelectCard = card.Number
+	}
+	v.status = fmt.Sprintf("Moved #%d to %s", card.Number, col.Name)
+	return v.loadCards
+}
+
+func (v *CardListView) renderMovePicker() string {
+	s := v.styles
+
+	var items []string
+	for i, col := range v.moveTargets() {
+		itemStyle := s.ListItem
+		if i == v.moveColumnCursor {
+			itemStyle = s.ListSelected
+		}
+		label := col.Name
+		if col.ID == v.moveCard.ColumnID {
+			label += " (current)"
+		}
+		items = append(items, itemStyle.Render(label))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render(fmt.Sprintf("Move #%d to...", v.moveCard.Number)),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, items...),
+		"",
+		s.TitleMuted.Render("↵: move • Esc: cancel"),
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
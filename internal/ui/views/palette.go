@@ -0,0 +1,171 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// Command is an entry in the command palette. Running it feeds Key back
+// through the view's own key handling, so the palette never duplicates the
+// logic behind an action.
+type Command struct {
+	Title string
+	Key   tea.KeyMsg
+}
+
+// Commander is implemented by views that contribute to the command palette.
+// Commands returns nil while the view is busy (editing, confirming, etc.), so
+// the palette only offers what the view would act on right now.
+type Commander interface {
+	Commands() []Command
+}
+
+// runeCommand builds a Command triggered by a plain key such as "n" or "?"
+func runeCommand(title, k string) Command {
+	return Command{Title: title, Key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}}
+}
+
+// RunCommand asks the app to close the palette and replay the command's key
+type RunCommand struct {
+	Key tea.KeyMsg
+}
+
+// CancelPalette closes the palette without running anything
+type CancelPalette struct{}
+
+// paletteRows is how many matching commands are listed at once
+const paletteRows = 10
+
+// PaletteView lists commands and narrows them as the user types
+type PaletteView struct {
+	styles   *styles.Styles
+	keys     keys.KeyMap
+	input    textinput.Model
+	commands []Command
+
+	width  int
+	height int
+	cursor int
+}
+
+func NewPaletteView(commands []Command) *PaletteView {
+	input := textinput.New()
+	input.Placeholder = "Type a command"
+	input.CharLimit = 50
+	input.Focus()
+
+	return &PaletteView{
+		styles:   styles.NewStyles(),
+		keys:     keys.DefaultKeyMap(),
+		input:    input,
+		commands: commands,
+	}
+}
+
+func (v *PaletteView) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// matches returns the commands whose title contains every word typed so far
+func (v *PaletteView) matches() []Command {
+	words := strings.Fields(strings.ToLower(v.input.Value()))
+	var result []Command
+	for _, c := range v.commands {
+		title := strings.ToLower(c.Title)
+		ok := true
+		for _, w := range words {
+			if !strings.Contains(title, w) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (v *PaletteView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+		return v, nil
+
+	case tea.KeyMsg:
+		// Letters go to the filter, so only arrows and ctrl keys move the cursor
+		switch {
+		case key.Matches(msg, v.keys.Back):
+			return v, func() tea.Msg { return CancelPalette{} }
+		case msg.String() == "up", msg.String() == "ctrl+p":
+			if v.cursor > 0 {
+				v.cursor--
+			}
+			return v, nil
+		case msg.String() == "down", msg.String() == "ctrl+n":
+			if v.cursor < len(v.matches())-1 {
+				v.cursor++
+			}
+			return v, nil
+		case key.Matches(msg, v.keys.Enter):
+			matches := v.matches()
+			if v.cursor >= len(matches) {
+				return v, nil
+			}
+			k := matches[v.cursor].Key
+			return v, func() tea.Msg { return RunCommand{Key: k} }
+		}
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	v.cursor = clamp(v.cursor, 0, max(len(v.matches())-1, 0))
+	return v, cmd
+}
+
+func (v *PaletteView) View() string {
+	s := v.styles
+	contentWidth := styles.ContentWidth(v.width)
+	width := clamp(contentWidth-6, 20, 50)
+
+	matches := v.matches()
+	start := 0
+	if v.cursor >= paletteRows {
+		start = v.cursor - paletteRows + 1
+	}
+	end := min(start+paletteRows, len(matches))
+
+	var rows []string
+	for i := start; i < end; i++ {
+		c := matches[i]
+		itemStyle := s.ListItem
+		if i == v.cursor {
+			itemStyle = s.ListSelected
+		}
+		hint := c.Key.String()
+		gap := max(width-lipgloss.Width(c.Title)-lipgloss.Width(hint)-2, 1)
+		rows = append(rows, itemStyle.Render(c.Title+strings.Repeat(" ", gap)+s.HelpKey.Render(hint)))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, s.TitleMuted.Render("No matching commands"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Commands"),
+		"",
+		s.InputFocused.Width(width).Render(v.input.View()),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		s.TitleMuted.Render("↑↓: move • ↵: run • Esc: cancel"),
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
@@ -0,0 +1,96 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// progressBarWidth is the number of cells in a completion bar.
+const progressBarWidth = 10
+
+// boardProgress is how many of a board's cards are closed. recent counts the
+// closed cards stm closed in the last week; the board list leaves it at zero.
+type boardProgress struct {
+	closed int
+	total  int
+	recent int
+}
+
+// recentWindow is how far back the "this week" badge counts closed cards.
+const recentWindow = 7 * 24 * time.Hour
+
+// countProgress counts a board's closed cards, and those stm closed in the
+// last week, out of cards, which must include the closed ones. It also notes
+// which cards are open, which decides whether a card is blocked.
+func countProgress(settings *fizzy.Settings, cards []models.Card) (boardProgress, map[int]bool) {
+	var progress boardProgress
+	open := make(map[int]bool)
+	weekAgo := time.Now().Add(-recentWindow)
+	for _, c := range cards {
+		progress.total++
+		if c.ColumnID == "done" {
+			progress.closed++
+			if at, ok := settings.ClosedAt(c.Number); ok && at.After(weekAgo) {
+				progress.recent++
+			}
+		} else {
+			open[c.Number] = true
+		}
+	}
+	return progress, open
+}
+
+// renderRecentBadge shows how many cards were closed in the last week, or
+// nothing when none were. Cards closed outside stm have no close time and
+// aren't counted.
+func renderRecentBadge(p boardProgress) string {
+	if p.recent == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(styles.Current.Success).Render(fmt.Sprintf("✓ %d this week", p.recent))
+}
+
+// renderProgressBar draws a completion bar with its percentage, or nothing for an empty board.
+func renderProgressBar(s *styles.Styles, p boardProgress) string {
+	if p.total == 0 {
+		return ""
+	}
+	filled := p.closed * progressBarWidth / p.total
+	bar := lipgloss.NewStyle().Foreground(styles.Current.Success).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(styles.Current.Border).Render(strings.Repeat("░", progressBarWidth-filled))
+	return bar + " " + s.TitleMuted.Render(fmt.Sprintf("%d%% (%d/%d)", p.closed*100/p.total, p.closed, p.total))
+}
+
+// workloadDots is the number of dots in a workload indicator.
+const workloadDots = 5
+
+// open is how many of the board's cards are still open.
+func (p boardProgress) open() int {
+	return p.total - p.closed
+}
+
+// renderWorkload draws dots scaled to a board's open cards relative to the
+// busiest board's, colored from calm to heavy. Boards with nothing open get nothing.
+func renderWorkload(s *styles.Styles, p boardProgress, maxOpen int) string {
+	open := p.open()
+	if open <= 0 || maxOpen <= 0 {
+		return ""
+	}
+	filled := max(open*workloadDots/maxOpen, 1)
+	color := styles.Current.Success
+	switch {
+	case filled >= workloadDots-1:
+		color = styles.Current.Error
+	case filled >= workloadDots/2:
+		color = styles.Current.Warning
+	}
+	dots := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("●", filled)) +
+		lipgloss.NewStyle().Foreground(styles.Current.Border).Render(strings.Repeat("○", workloadDots-filled))
+	return dots + " " + s.TitleMuted.Render(fmt.Sprintf("%d open", open))
+}
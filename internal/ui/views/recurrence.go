@@ -0,0 +1,61 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tgienger/stm/internal/dates"
+	"github.com/tgienger/stm/internal/models"
+)
+
+// scheduleNext creates the next occurrence of a repeating card that was just
+// closed, snoozed until it falls due, and hands the repeat rule over to it.
+// It returns a suffix for the close status, which is empty for other cards.
+func (v *CardListView) scheduleNext(card models.Card) string {
+	v.lastRepeatCard = 0
+	rule := v.settings.Recurrence(card.Number)
+	if rule == "" {
+		return ""
+	}
+	due, err := dates.NextRecurrence(rule, time.Now())
+	if err != nil {
+		return ""
+	}
+
+	next, err := v.fizzy.CreateCard(v.board.ID, card.Title, card.Description)
+	if err != nil {
+		return " • couldn't create the next one"
+	}
+	for _, tag := range card.Tags {
+		v.fizzy.AddTag(next, tag)
+	}
+	_ = v.settings.SetRecurrence(next.Number, rule)
+	_ = v.settings.SetRecurrence(card.Number, "")
+	_ = v.settings.SnoozeCard(next.Number, due)
+	v.lastRepeatCard = next.Number
+	return fmt.Sprintf(" • next #%d on %s", next.Number, due.Format("Jan 2"))
+}
+
+// unscheduleNext deletes the occurrence scheduleNext created when card was
+// closed, giving the repeat rule back to card. It is used by undo.
+func (v *CardListView) unscheduleNext(card int) {
+	next := v.lastRepeatCard
+	if next == 0 {
+		return
+	}
+	v.lastRepeatCard = 0
+	_ = v.settings.SetRecurrence(card, v.settings.Recurrence(next))
+	if err := v.fizzy.DeleteCard(next); err != nil {
+		return
+	}
+	_ = v.settings.SetRecurrence(next, "")
+	_ = v.settings.UnsnoozeCard(next)
+}
+
+// repeatSummary describes how often a card repeats for the detail view
+func (v *CardListView) repeatSummary(card models.Card) string {
+	if rule := v.settings.Recurrence(card.Number); rule != "" {
+		return rule
+	}
+	return v.styles.TitleMuted.Render("Never")
+}
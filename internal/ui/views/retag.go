@@ -0,0 +1,145 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+type retagStep int
+
+const (
+	retagPickSource retagStep = iota
+	retagPickTarget
+	retagConfirm
+)
+
+func (v *CardListView) startRetag() {
+	v.retagging = true
+	v.retagStep = retagPickSource
+	v.retagCursor = 0
+	v.retagFrom = ""
+}
+
+func (v *CardListView) updateRetagging(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if v.retagStep == retagConfirm {
+		switch msg.String() {
+		case "a", "A":
+			return v, v.applyRetag(false)
+		case "r", "R":
+			return v, v.applyRetag(true)
+		case "n", "N", "esc":
+			v.retagging = false
+		}
+		return v, nil
+	}
+
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		if v.retagStep == retagPickTarget {
+			v.retagStep = retagPickSource
+			v.retagCursor = 0
+			return v, nil
+		}
+		v.retagging = false
+		return v, nil
+
+	case key.Matches(msg, v.keys.Up):
+		if v.retagCursor > 0 {
+			v.retagCursor--
+		}
+		return v, nil
+
+	case key.Matches(msg, v.keys.Down):
+		if v.retagCursor < len(v.tags)-1 {
+			v.retagCursor++
+		}
+		return v, nil
+
+	case key.Matches(msg, v.keys.Enter):
+		if v.retagCursor >= len(v.tags) {
+			return v, nil
+		}
+		picked := v.tags[v.retagCursor].Title
+		if v.retagStep == retagPickSource {
+			v.retagFrom = picked
+			v.retagStep = retagPickTarget
+			v.retagCursor = 0
+			return v, nil
+		}
+		if picked == v.retagFrom {
+			return v, nil
+		}
+		v.retagTo = picked
+		v.retagStep = retagConfirm
+		return v, nil
+	}
+
+	return v, nil
+}
+
+func (v *CardListView) applyRetag(removeSource bool) tea.Cmd {
+	v.retagging = false
+	changed, err := v.fizzy.RetagCards(v.retagFrom, v.retagTo, removeSource)
+	if err != nil {
+		v.status = "Retag failed: " + err.Error()
+	} else {
+		v.status = fmt.Sprintf("Retagged %d cards from %s to %s", changed, v.retagFrom, v.retagTo)
+	}
+	return tea.Batch(v.loadCards, v.loadTagCounts)
+}
+
+func (v *CardListView) renderRetag() string {
+	s := v.styles
+
+	var content string
+	if v.retagStep == retagConfirm {
+		content = lipgloss.JoinVertical(lipgloss.Center,
+			s.Title.Render("Retag Cards"),
+			"",
+			fmt.Sprintf("Add %s to every card tagged %s", v.retagTo, v.retagFrom),
+			"",
+			lipgloss.JoinHorizontal(lipgloss.Center,
+				s.Button.Render(" A - Add "),
+				"  ",
+				s.ButtonPrimary.Render(" R - Replace "),
+				"  ",
+				s.Button.Render(" N - Cancel "),
+			),
+		)
+	} else {
+		heading := "Retag: pick the tag to replace"
+		if v.retagStep == retagPickTarget {
+			heading = "Retag " + v.retagFrom + " as..."
+		}
+
+		var items []string
+		for i, tag := range v.tags {
+			itemStyle := s.ListItem
+			if i == v.retagCursor {
+				itemStyle = s.ListSelected
+			}
+			label := tagLabel(tag.Title)
+			if v.retagStep == retagPickTarget && tag.Title == v.retagFrom {
+				label = s.TitleMuted.Render(label)
+			}
+			items = append(items, itemStyle.Render(label))
+		}
+		if len(items) == 0 {
+			items = append(items, s.TitleMuted.Render("No tags available"))
+		}
+
+		content = s.FilterBar.Render(lipgloss.JoinVertical(lipgloss.Left,
+			s.Title.Render(heading),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, items...),
+			"",
+			s.TitleMuted.Render("↵: select • Esc: back"),
+		))
+	}
+
+	return styles.CenterViewMiddle(content, v.width, v.height)
+}
@@ -0,0 +1,76 @@
+package views
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+// searchQuery is the search box input split into filters and free text.
+// Recognized filters are tag:<name>, is:open and is:closed; anything else,
+// including unknown prefixes, is matched as plain text.
+type searchQuery struct {
+	text   string
+	tags   []string
+	closed *bool
+}
+
+func parseSearchQuery(input string) searchQuery {
+	var q searchQuery
+	var words []string
+	for _, word := range strings.Fields(input) {
+		prefix, value, ok := strings.Cut(word, ":")
+		switch {
+		case ok && strings.EqualFold(prefix, "tag") && value != "":
+			q.tags = append(q.tags, value)
+		case ok && strings.EqualFold(prefix, "is") && (strings.EqualFold(value, "closed") || strings.EqualFold(value, "done")):
+			closed := true
+			q.closed = &closed
+		case ok && strings.EqualFold(prefix, "is") && strings.EqualFold(value, "open"):
+			closed := false
+			q.closed = &closed
+		default:
+			words = append(words, word)
+		}
+	}
+	q.text = strings.ToLower(strings.Join(words, " "))
+	return q
+}
+
+func (q searchQuery) matches(c models.Card) bool {
+	return q.matchesText(c) && q.matchesFilters(c)
+}
+
+// matchesText reports whether the free text appears in the card's title or description.
+func (q searchQuery) matchesText(c models.Card) bool {
+	return q.text == "" || strings.Contains(strings.ToLower(c.Title), q.text) ||
+		strings.Contains(strings.ToLower(c.Description), q.text)
+}
+
+// matchesTitle reports whether the free text appears in the card's title.
+func (q searchQuery) matchesTitle(c models.Card) bool {
+	return q.text != "" && strings.Contains(strings.ToLower(c.Title), q.text)
+}
+
+// matchesAny reports whether the free text appears in any of the given lowercased texts,
+// such as comment bodies or an external reference.
+func (q searchQuery) matchesAny(texts []string) bool {
+	if q.text == "" {
+		return false
+	}
+	return slices.ContainsFunc(texts, func(t string) bool { return strings.Contains(t, q.text) })
+}
+
+// matchesFilters checks the tag: and is: filters.
+func (q searchQuery) matchesFilters(c models.Card) bool {
+	for _, tag := range q.tags {
+		if !slices.ContainsFunc(c.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+	if q.closed != nil && (c.ColumnID == "done") != *q.closed {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/export"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// startShare opens the export menu for the given cards; label describes them in the menu title.
+func (v *CardListView) startShare(cards []models.Card, label string) {
+	if len(cards) == 0 {
+		v.status = "Nothing to export"
+		return
+	}
+	v.sharing = true
+	v.shareCards = cards
+	v.shareLabel = label
+}
+
+func (v *CardListView) updateSharing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var buf bytes.Buffer
+	var err error
+	format := ""
+
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.sharing = false
+		return v, nil
+	case msg.String() == "j":
+		format = "JSON"
+		err = export.JSON(&buf, v.board, v.shareCards)
+	case msg.String() == "c":
+		format = "CSV"
+		err = export.CSV(&buf, v.shareCards)
+	case msg.String() == "m":
+		format = "Markdown"
+		err = export.Markdown(&buf, v.board, v.shareCards)
+	case msg.String() == "p":
+		format = "plain text"
+		err = export.Plain(&buf, v.shareCards)
+	case msg.String() == "f":
+		v.sharing = false
+		v.status = v.exportToFile(v.shareCards)
+		return v, nil
+	default:
+		return v, nil
+	}
+
+	v.sharing = false
+	if err == nil {
+		err = clipboard.WriteAll(buf.String())
+	}
+	if err != nil {
+		v.status = "Copy failed: " + err.Error()
+		return v, nil
+	}
+	v.status = fmt.Sprintf("Copied %s as %s", v.shareLabel, format)
+	return v, nil
+}
+
+func (v *CardListView) renderShareMenu() string {
+	s := v.styles
+
+	options := []string{
+		s.HelpKey.Render("j") + "  copy as JSON",
+		s.HelpKey.Render("c") + "  copy as CSV",
+		s.HelpKey.Render("m") + "  copy as Markdown",
+		s.HelpKey.Render("p") + "  copy as plain text",
+		s.HelpKey.Render("f") + "  save Markdown file",
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render("Export "+v.shareLabel),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, options...),
+		"",
+		s.TitleMuted.Render("Esc: cancel"),
+	)
+
+	return styles.CenterViewMiddle(s.FilterBar.Render(content), v.width, v.height)
+}
@@ -0,0 +1,39 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tgienger/stm/internal/models"
+)
+
+// isShelved reports whether an open card has been shelved. Closing a card
+// takes it off the shelf, so closed cards are never treated as shelved.
+func (v *CardListView) isShelved(card models.Card) bool {
+	return card.ColumnID != "done" && v.settings.IsShelved(card.Number)
+}
+
+// toggleShelved moves an open card onto or off the shelf. Shelved cards stay
+// open in fizzy but are hidden from the list until Y shows the shelf.
+func (v *CardListView) toggleShelved(card models.Card) {
+	switch {
+	case card.ColumnID == "done":
+		v.status = "Only open cards can be shelved"
+		return
+	case v.isShelved(card):
+		if err := v.settings.UnshelveCard(card.Number); err != nil {
+			v.status = fmt.Sprintf("Failed to unshelve #%d", card.Number)
+			return
+		}
+		v.status = fmt.Sprintf("Unshelved #%d", card.Number)
+	default:
+		if err := v.settings.ShelveCard(card.Number, time.Now()); err != nil {
+			v.status = fmt.Sprintf("Failed to shelve #%d", card.Number)
+			return
+		}
+		v.status = fmt.Sprintf("Shelved #%d • Y to show the shelf", card.Number)
+	}
+	v.viewingCard = false
+	v.viewCardComments = nil
+	v.clampVisibleState()
+}
@@ -0,0 +1,83 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/dates"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// isSnoozed reports whether a card is hidden by an active snooze.
+func (v *CardListView) isSnoozed(card models.Card) bool {
+	_, snoozed := v.settings.SnoozedUntil(card.Number, time.Now())
+	return snoozed
+}
+
+func (v *CardListView) startSnooze(card models.Card) tea.Cmd {
+	v.snoozing = true
+	v.snoozeCardNumber = card.Number
+	v.snoozeError = ""
+	v.snoozeInput.Reset()
+	v.snoozeInput.Focus()
+	return textinput.Blink
+}
+
+func (v *CardListView) updateSnoozing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, v.keys.Back):
+		v.snoozing = false
+		v.snoozeInput.Blur()
+		return v, nil
+
+	case key.Matches(msg, v.keys.Enter):
+		until, err := dates.ParseRelative(v.snoozeInput.Value(), time.Now())
+		if err != nil {
+			v.snoozeError = err.Error()
+			return v, nil
+		}
+		if err := v.settings.SnoozeCard(v.snoozeCardNumber, until); err != nil {
+			v.snoozeError = err.Error()
+			return v, nil
+		}
+		v.snoozing = false
+		v.snoozeInput.Blur()
+		v.viewingCard = false
+		v.viewCardComments = nil
+		v.status = fmt.Sprintf("Snoozed #%d until %s", v.snoozeCardNumber, until.Format("Jan 2, 2006 3:04 PM"))
+		v.clampVisibleState()
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	v.snoozeError = ""
+	v.snoozeInput, cmd = v.snoozeInput.Update(msg)
+	return v, cmd
+}
+
+func (v *CardListView) renderSnoozePrompt() string {
+	s := v.styles
+	contentWidth := styles.ContentWidth(v.width)
+	inputWidth := clamp(contentWidth-6, 20, 40)
+
+	hint := s.TitleMuted.Render("+1d, +2w, +4h, tomorrow, next week, 2025-01-02")
+	if v.snoozeError != "" {
+		hint = lipgloss.NewStyle().Foreground(styles.Current.Error).Render(v.snoozeError)
+	}
+
+	form := lipgloss.JoinVertical(lipgloss.Left,
+		s.Title.Render(fmt.Sprintf("Snooze #%d until...", v.snoozeCardNumber)),
+		"",
+		s.InputFocused.Width(inputWidth).Render(v.snoozeInput.View()),
+		hint,
+		"",
+		s.TitleMuted.Render("↵: snooze • Esc: cancel"),
+	)
+
+	return styles.CenterViewMiddle(form, v.width, v.height)
+}
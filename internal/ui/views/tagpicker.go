@@ -0,0 +1,143 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/models"
+	"github.com/tgienger/stm/internal/ui/keys"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// tagPicker is the filterable, scrolling tag list behind the tag assignment
+// popup and the edit form's tag selector. / starts typing a filter; while
+// typing, letters go to the filter and only the arrow keys move the cursor.
+type tagPicker struct {
+	filter    textinput.Model
+	filtering bool
+	cursor    int // index into matches
+	scrollY   int
+}
+
+func newTagPicker() tagPicker {
+	filter := textinput.New()
+	filter.Placeholder = "Filter tags"
+	filter.Prompt = "/"
+	filter.CharLimit = 50
+	return tagPicker{filter: filter}
+}
+
+func (p *tagPicker) reset() {
+	p.filter.Reset()
+	p.filter.Blur()
+	p.filtering = false
+	p.cursor = 0
+	p.scrollY = 0
+}
+
+// matches returns the tags whose title contains the filter text
+func (p *tagPicker) matches(tags []models.Tag) []models.Tag {
+	query := strings.ToLower(strings.TrimSpace(p.filter.Value()))
+	if query == "" {
+		return tags
+	}
+	var result []models.Tag
+	for _, t := range tags {
+		if strings.Contains(strings.ToLower(t.Title), query) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// selected returns the tag under the cursor, if any tag matches
+func (p *tagPicker) selected(tags []models.Tag) (models.Tag, bool) {
+	matches := p.matches(tags)
+	if p.cursor < 0 || p.cursor >= len(matches) {
+		return models.Tag{}, false
+	}
+	return matches[p.cursor], true
+}
+
+// update handles navigation and filter keys. It reports false for keys it
+// leaves to the caller, such as Enter, space and Esc outside the filter.
+func (p *tagPicker) update(msg tea.KeyMsg, km keys.KeyMap, tags []models.Tag) (bool, tea.Cmd) {
+	count := len(p.matches(tags))
+
+	if p.filtering {
+		switch msg.String() {
+		case "esc", "enter":
+			p.filtering = false
+			p.filter.Blur()
+			return true, nil
+		case "up":
+			p.cursor = max(p.cursor-1, 0)
+			return true, nil
+		case "down":
+			p.cursor = clamp(p.cursor+1, 0, max(count-1, 0))
+			return true, nil
+		}
+		var cmd tea.Cmd
+		p.filter, cmd = p.filter.Update(msg)
+		p.cursor = 0
+		p.scrollY = 0
+		return true, cmd
+	}
+
+	switch {
+	case msg.String() == "/":
+		p.filtering = true
+		return true, p.filter.Focus()
+	case key.Matches(msg, km.Up):
+		p.cursor = max(p.cursor-1, 0)
+		return true, nil
+	case key.Matches(msg, km.Down):
+		p.cursor = clamp(p.cursor+1, 0, max(count-1, 0))
+		return true, nil
+	}
+	return false, nil
+}
+
+// render lists up to rows matching tags around the cursor, drawing each with
+// label. highlight is false when the picker doesn't have focus.
+func (p *tagPicker) render(s *styles.Styles, tags []models.Tag, rows int, highlight bool, label func(models.Tag) string) string {
+	var lines []string
+	if p.filtering || p.filter.Value() != "" {
+		lines = append(lines, p.filter.View())
+	}
+
+	matches := p.matches(tags)
+	switch {
+	case len(tags) == 0:
+		return s.TitleMuted.Render("No tags available")
+	case len(matches) == 0:
+		return lipgloss.JoinVertical(lipgloss.Left, append(lines, s.TitleMuted.Render("No matching tags"))...)
+	}
+
+	rows = max(rows, 1)
+	if p.cursor < p.scrollY {
+		p.scrollY = p.cursor
+	} else if p.cursor >= p.scrollY+rows {
+		p.scrollY = p.cursor - rows + 1
+	}
+	p.scrollY = clamp(p.scrollY, 0, max(len(matches)-rows, 0))
+	end := min(p.scrollY+rows, len(matches))
+
+	if p.scrollY > 0 {
+		lines = append(lines, s.TitleMuted.Render("  ↑ more"))
+	}
+	for i := p.scrollY; i < end; i++ {
+		itemStyle := s.ListItem
+		if highlight && i == p.cursor {
+			itemStyle = s.ListSelected
+		}
+		lines = append(lines, itemStyle.Render(label(matches[i])))
+	}
+	if end < len(matches) {
+		lines = append(lines, s.TitleMuted.Render("  ↓ more"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
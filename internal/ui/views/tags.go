@@ -0,0 +1,34 @@
+package views
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tgienger/stm/internal/fizzy"
+	"github.com/tgienger/stm/internal/ui/styles"
+)
+
+// tagGlyph marks a tag everywhere tags are shown
+const tagGlyph = "●"
+
+// tagLabel is the plain form of a tag, used inside pickers where the row style
+// (selected or not) must apply to the whole line.
+func tagLabel(title string) string {
+	return tagGlyph + " " + title
+}
+
+// renderTags renders a card's tags with the shared tag style, or a muted
+// placeholder when there are none.
+func renderTags(s *styles.Styles, tags []string) string {
+	if len(tags) == 0 {
+		return s.TitleMuted.Render("no tags")
+	}
+	parts := make([]string, len(tags))
+	for i, t := range tags {
+		parts[i] = s.Tag.Render(tagLabel(t))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// tagColorRules returns the tag_colors row color rules keyed by lowercased tag name.
+func tagColorRules(settings *fizzy.Settings) map[string]string {
+	return settings.TagColors()
+}